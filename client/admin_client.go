@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"otmane/pcbook/pb"
+	"otmane/pcbook/serializer"
+
+	"google.golang.org/grpc"
+)
+
+// AdminClient is a client to call the administration RPCs
+type AdminClient struct {
+	service pb.AdminServiceClient
+}
+
+// NewAdminClient returns a new admin client
+func NewAdminClient(cc *grpc.ClientConn) *AdminClient {
+	return &AdminClient{service: pb.NewAdminServiceClient(cc)}
+}
+
+// Snapshot saves a snapshot of the server to the file, which is replaced only once the whole
+// snapshot is received
+func (c *AdminClient) Snapshot(filename string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	stream, err := c.service.Snapshot(ctx, &pb.SnapshotRequest{})
+	if err != nil {
+		return fmt.Errorf("cannot take snapshot: %v", err)
+	}
+
+	writer, err := serializer.CreateDelimitedFile(filename)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	records := 0
+	for {
+		record, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("cannot receive snapshot record: %v", err)
+		}
+
+		err = writer.Write(record)
+		if err != nil {
+			return err
+		}
+		records++
+	}
+
+	err = writer.Commit()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("saved snapshot of %d records to %s", records, filename)
+	return nil
+}
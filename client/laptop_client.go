@@ -13,6 +13,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 
 	"otmane/pcbook/pb"
 )
@@ -52,14 +53,82 @@ func (l *LaptopClient) CreateLaptop(laptop *pb.Laptop) {
 	log.Printf("created laptop with ID: %v", res.Id)
 }
 
+func (l *LaptopClient) GetLaptop(laptopID string) (*pb.Laptop, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := &pb.GetLaptopRequest{
+		Id: laptopID,
+	}
+
+	res, err := l.service.GetLaptop(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get laptop: %v", err)
+	}
+
+	return res.GetLaptop(), nil
+}
+
+// UpdateLaptop updates the given fields of the laptop, or the whole laptop when no path is given.
+// The update fails with codes.Aborted if the stored laptop is not at the revision of the given one.
+func (l *LaptopClient) UpdateLaptop(laptop *pb.Laptop, paths ...string) (*pb.Laptop, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := &pb.UpdateLaptopRequest{
+		Laptop:           laptop,
+		UpdateMask:       &fieldmaskpb.FieldMask{Paths: paths},
+		ExpectedRevision: laptop.GetRevision(),
+	}
+
+	res, err := l.service.UpdateLaptop(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot update laptop: %v", err)
+	}
+
+	log.Printf("updated laptop with ID: %v", res.GetLaptop().GetId())
+	return res.GetLaptop(), nil
+}
+
+// DeleteLaptop deletes a laptop, the revision must match the stored one unless it is 0
+func (l *LaptopClient) DeleteLaptop(laptopID string, revision uint64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := &pb.DeleteLaptopRequest{
+		Id:               laptopID,
+		ExpectedRevision: revision,
+	}
+
+	_, err := l.service.DeleteLaptop(ctx, req)
+	if err != nil {
+		return fmt.Errorf("cannot delete laptop: %v", err)
+	}
+
+	log.Printf("deleted laptop with ID: %v", laptopID)
+	return nil
+}
+
 func (l *LaptopClient) SearchLaptop(filter *pb.Filter) {
 	log.Print("search filter: ", filter)
 
+	pageToken := ""
+	for {
+		pageToken = l.searchLaptopPage(filter, pageToken)
+		if pageToken == "" {
+			return
+		}
+	}
+}
+
+// searchLaptopPage logs one page of the search and returns the token of the next one
+func (l *LaptopClient) searchLaptopPage(filter *pb.Filter, pageToken string) string {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
 	searchLaptopRequest := &pb.SearchLaptopRequest{
-		Filter: filter,
+		Filter:    filter,
+		PageToken: pageToken,
 	}
 
 	stream, err := l.service.SearchLaptop(ctx, searchLaptopRequest)
@@ -71,11 +140,14 @@ func (l *LaptopClient) SearchLaptop(filter *pb.Filter) {
 		res, err := stream.Recv()
 		if err == io.EOF {
 			// Done with receiving from the stream
-			return
+			return ""
 		}
 		if err != nil {
 			log.Fatal("cannot receive the response: ", err)
 		}
+		if res.GetNextPageToken() != "" {
+			return res.GetNextPageToken()
+		}
 
 		laptop := res.Laptop
 		log.Print("- Found: ", laptop.GetId())
@@ -95,7 +167,7 @@ func (l *LaptopClient) UploadImage(laptopId string, imagePath string) {
 
 	log.Printf("laptopID = %s", laptopId)
 
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
 	defer cancel()
 
 	stream, err := l.service.UploadImage(ctx)
@@ -117,7 +189,7 @@ func (l *LaptopClient) UploadImage(laptopId string, imagePath string) {
 	}
 
 	reader := bufio.NewReader(file)
-	buffer := make([]byte, 1024)
+	buffer := make([]byte, 64*1024)
 
 	for {
 		n, err := reader.Read(buffer)
@@ -147,6 +219,100 @@ func (l *LaptopClient) UploadImage(laptopId string, imagePath string) {
 	log.Printf("image is successfully uploaded with ID: %s and size %d", res.GetId(), res.GetSize())
 }
 
+// DownloadImage downloads an image to the folder, in a file named by its ID and type, and returns its path.
+// The file is created only once the whole image is received.
+func (l *LaptopClient) DownloadImage(imageID string, imageFolder string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	stream, err := l.service.DownloadImage(ctx, &pb.DownloadImageRequest{ImageId: imageID})
+	if err != nil {
+		return "", fmt.Errorf("cannot download image: %v", err)
+	}
+
+	res, err := stream.Recv()
+	if err != nil {
+		return "", fmt.Errorf("cannot receive image info: %v", err)
+	}
+	info := res.GetInfo()
+	if info == nil {
+		return "", fmt.Errorf("cannot receive image info: got %T", res.GetData())
+	}
+
+	file, err := os.CreateTemp(imageFolder, imageID+".*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("cannot create image file: %v", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	imageSize := 0
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("cannot receive chunk data: %v", err)
+		}
+
+		n, err := file.Write(res.GetChunkData())
+		if err != nil {
+			return "", fmt.Errorf("cannot write chunk data: %v", err)
+		}
+		imageSize += n
+	}
+
+	err = file.Close()
+	if err != nil {
+		return "", fmt.Errorf("cannot write image file: %v", err)
+	}
+
+	imagePath := filepath.Join(imageFolder, filepath.Base(imageID+info.GetImageType()))
+	err = os.Rename(file.Name(), imagePath)
+	if err != nil {
+		return "", fmt.Errorf("cannot rename image file: %v", err)
+	}
+
+	log.Printf("image %s of laptop %s is successfully downloaded with size %d", imageID, info.GetLaptopId(), imageSize)
+	return imagePath, nil
+}
+
+// ListLaptopImages returns the images of a laptop ordered by ID
+func (l *LaptopClient) ListLaptopImages(laptopID string) ([]*pb.LaptopImage, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := &pb.ListLaptopImagesRequest{
+		LaptopId: laptopID,
+	}
+
+	res, err := l.service.ListLaptopImages(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list laptop images: %v", err)
+	}
+
+	return res.GetImages(), nil
+}
+
+// DeleteImage deletes an image and its data
+func (l *LaptopClient) DeleteImage(imageID string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req := &pb.DeleteImageRequest{
+		ImageId: imageID,
+	}
+
+	_, err := l.service.DeleteImage(ctx, req)
+	if err != nil {
+		return fmt.Errorf("cannot delete image: %v", err)
+	}
+
+	log.Printf("deleted image with ID: %v", imageID)
+	return nil
+}
+
 func (l *LaptopClient) RateLaptop(laptopIDs []string, scores []float64) error {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -62,13 +62,18 @@ func authMethods() map[string]bool {
 
 	return map[string]bool{
 		laptopServicePath + "CreateLaptop": true,
+		laptopServicePath + "UpdateLaptop": true,
+		laptopServicePath + "DeleteLaptop": true,
 		laptopServicePath + "UplaodImage":  true,
+		laptopServicePath + "DeleteImage":  true,
 		laptopServicePath + "RateLaptop":   true,
+		"/pb.AdminService/Snapshot":        true,
 	}
 }
 
 func main() {
 	serverAddress := flag.String("address", "", "the server's address")
+	snapshotPath := flag.String("snapshot", "", "the file to save a snapshot of the server to, instead of rating laptops")
 	flag.Parse()
 	log.Printf("Dial server %s", *serverAddress)
 
@@ -93,6 +98,14 @@ func main() {
 		panic(err)
 	}
 
+	if *snapshotPath != "" {
+		err := client.NewAdminClient(cc2).Snapshot(*snapshotPath)
+		if err != nil {
+			log.Fatal("Cannot save snapshot: ", err)
+		}
+		return
+	}
+
 	laptopCLient := client.NewLaptopClient(cc2)
 	testRateLaptop(laptopCLient)
 }
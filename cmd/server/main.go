@@ -1,10 +1,12 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"net"
+	"strings"
 	"time"
 
 	"otmane/pcbook/pb"
@@ -34,7 +36,13 @@ func createUser(userStore service.UserStore, username, password, role string) er
 		return err
 	}
 
-	return userStore.Save(user)
+	err = userStore.Save(user)
+	if errors.Is(err, service.ErrAlreadyExists) {
+		// the user was seeded by a previous run of the server
+		return nil
+	}
+
+	return err
 }
 
 func accessibleRoles() map[string][]string {
@@ -42,22 +50,116 @@ func accessibleRoles() map[string][]string {
 
 	return map[string][]string{
 		laptopServicePath + "CreateLaptop": {"admin"},
+		laptopServicePath + "UpdateLaptop": {"admin"},
+		laptopServicePath + "DeleteLaptop": {"admin"},
 		laptopServicePath + "UploadImage":  {"admin"},
+		laptopServicePath + "DeleteImage":  {"admin"},
 		laptopServicePath + "RateLaptop":   {"admin", "user"},
+		"/pb.AdminService/Snapshot":        {"admin"},
+	}
+}
+
+// openStores returns the laptop, rating and user stores, in the SQLite database if sqlitePath
+// is set, with the laptops in the data directory if dataDir is set, and in memory otherwise,
+// with the laptops partitioned across the shards if there are more than one, which only the
+// in-memory store supports
+func openStores(dataDir, sqlitePath string, shards int) (service.LaptopStore, service.RatingStore, service.UserStore, error) {
+	switch {
+	case dataDir != "" && sqlitePath != "":
+		return nil, nil, nil, errors.New("cannot use both a data directory and a SQLite database")
+
+	case shards > 1 && (dataDir != "" || sqlitePath != ""):
+		return nil, nil, nil, errors.New("cannot shard the laptops of a data directory or a SQLite database")
+
+	case sqlitePath != "":
+		db, err := service.OpenSQLiteDB(sqlitePath)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return service.NewSQLiteLaptopStore(db), service.NewSQLiteRatingStore(db), service.NewSQLiteUserStore(db), nil
+
+	case dataDir != "":
+		laptopStore, err := service.NewFileLaptopStore(dataDir)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return laptopStore, service.NewInMemoryRatingStore(), service.NewInMemoryUserStore(), nil
+
+	case shards > 1:
+		return service.NewShardedLaptopStore(shards), service.NewInMemoryRatingStore(), service.NewInMemoryUserStore(), nil
+
+	default:
+		return service.NewInMemoryLaptopStore(), service.NewInMemoryRatingStore(), service.NewInMemoryUserStore(), nil
+	}
+}
+
+// restoreSnapshot restores the snapshot file to the empty stores, reading it whole before
+// saving anything so that a corrupted snapshot or non-empty stores leave them unchanged
+func restoreSnapshot(
+	filename string,
+	laptopStore service.LaptopStore,
+	ratingStore service.RatingStore,
+	userStore service.UserStore,
+	imageStore service.ImageStore,
+) error {
+	snapshot, err := service.ReadSnapshotFile(filename)
+	if err != nil {
+		return err
+	}
+
+	err = snapshot.Restore(laptopStore, ratingStore, userStore, imageStore)
+	if err != nil {
+		return err
 	}
+
+	log.Printf("restored snapshot of %s with %d laptops", snapshot.CreatedAt.Format(time.RFC3339), len(snapshot.Laptops))
+	return nil
 }
 
 func main() {
 	port := flag.Int("port", 5050, "the server port")
 	enableTls := flag.Bool("tls", false, "enable SSL/TLS")
+	dataDir := flag.String("data-dir", "", "the directory to save the laptops to, they are kept in memory only if empty")
+	sqlitePath := flag.String("sqlite", "", "the SQLite database file to save the laptops, ratings and users to")
+	restorePath := flag.String("restore", "", "the snapshot file to restore to the empty stores at startup")
+	shards := flag.Int("shards", 1, "the number of independently locked shards of the in-memory laptop store")
+	cacheSize := flag.Int("cache-size", 0, "the number of laptops to cache in front of the laptop store, 0 to disable the cache")
+	cacheTTL := flag.Duration("cache-ttl", 0, "the time laptops stay cached, 0 to keep them until they are written or evicted")
+	imageFormats := flag.String("image-formats", strings.Join(service.ImageFormats(), ","), "the comma separated formats of the images which can be uploaded")
 	flag.Parse()
+
+	allowedImageFormats, err := service.ParseImageFormats(*imageFormats)
+	if err != nil {
+		log.Fatal("Invalid image formats: ", err)
+	}
+
 	log.Printf("Start server on port: %d, TLS = %t", *port, *enableTls)
 
-	laptopStore := service.NewInMemoryLaptopStore()
-	imageStore := service.NewDiskImageStore("img")
-	ratingStore := service.NewInMemoryRatingStore()
+	laptopStore, ratingStore, userStore, err := openStores(*dataDir, *sqlitePath, *shards)
+	if err != nil {
+		log.Fatal("Cannot open stores: ", err)
+	}
+	if *cacheSize > 0 {
+		laptopStore = service.NewCachedLaptopStore(laptopStore, *cacheSize, *cacheTTL)
+	}
+	imageStore, imageReport, err := service.OpenDiskImageStore("img")
+	if err != nil {
+		log.Fatal("Cannot open image store: ", err)
+	}
+	for _, name := range imageReport.Orphans {
+		log.Printf("image file img/%s is not in the image index", name)
+	}
+	for _, imageID := range imageReport.Missing {
+		log.Printf("image %s of the image index has no file, it was removed from the index", imageID)
+	}
+
+	if *restorePath != "" {
+		err := restoreSnapshot(*restorePath, laptopStore, ratingStore, userStore, imageStore)
+		if err != nil {
+			log.Fatal("Cannot restore snapshot: ", err)
+		}
+	}
 
-	userStore := service.NewInMemoryUserStore()
 	jwtManager := service.NewJWTManager(secretKey, tokenDuration)
 
 	if err := seedUsers(userStore); err != nil {
@@ -75,9 +177,12 @@ func main() {
 	authServer := service.NewAuthServer(userStore, *jwtManager)
 
 	laptopServer := service.NewLaptopServer(laptopStore, imageStore, ratingStore)
+	laptopServer.ImageFormats = allowedImageFormats
+	adminServer := service.NewAdminServer(laptopServer, userStore)
 
 	pb.RegisterLaptopServiceServer(grpcServer, laptopServer)
 	pb.RegisterAuthServiceServer(grpcServer, authServer)
+	pb.RegisterAdminServiceServer(grpcServer, adminServer)
 
 	address := fmt.Sprintf("0.0.0.0:%d", *port)
 	listener, err := net.Listen("tcp", address)
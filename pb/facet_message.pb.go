@@ -0,0 +1,409 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.12.4
+// source: facet_message.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type FacetCount struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value string `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Count uint32 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *FacetCount) Reset() {
+	*x = FacetCount{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_facet_message_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FacetCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FacetCount) ProtoMessage() {}
+
+func (x *FacetCount) ProtoReflect() protoreflect.Message {
+	mi := &file_facet_message_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FacetCount.ProtoReflect.Descriptor instead.
+func (*FacetCount) Descriptor() ([]byte, []int) {
+	return file_facet_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FacetCount) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *FacetCount) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type HistogramBucket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Inclusive lower bound, not set for the first bucket.
+	Min *float64 `protobuf:"fixed64,1,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	// Exclusive upper bound, not set for the last bucket.
+	Max   *float64 `protobuf:"fixed64,2,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	Count uint32   `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+}
+
+func (x *HistogramBucket) Reset() {
+	*x = HistogramBucket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_facet_message_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HistogramBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HistogramBucket) ProtoMessage() {}
+
+func (x *HistogramBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_facet_message_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HistogramBucket.ProtoReflect.Descriptor instead.
+func (*HistogramBucket) Descriptor() ([]byte, []int) {
+	return file_facet_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *HistogramBucket) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *HistogramBucket) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+func (x *HistogramBucket) GetCount() uint32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Facets counts the laptops by value of some of their fields, laptops with
+// several GPUs or storages are counted once for each distinct value.
+type Facets struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Total           uint32             `protobuf:"varint,1,opt,name=total,proto3" json:"total,omitempty"`
+	Brands          []*FacetCount      `protobuf:"bytes,2,rep,name=brands,proto3" json:"brands,omitempty"`
+	CpuBrands       []*FacetCount      `protobuf:"bytes,3,rep,name=cpu_brands,json=cpuBrands,proto3" json:"cpu_brands,omitempty"`
+	GpuBrands       []*FacetCount      `protobuf:"bytes,4,rep,name=gpu_brands,json=gpuBrands,proto3" json:"gpu_brands,omitempty"`
+	RamGb           []*HistogramBucket `protobuf:"bytes,5,rep,name=ram_gb,json=ramGb,proto3" json:"ram_gb,omitempty"`
+	StorageDrivers  []*FacetCount      `protobuf:"bytes,6,rep,name=storage_drivers,json=storageDrivers,proto3" json:"storage_drivers,omitempty"`
+	Panels          []*FacetCount      `protobuf:"bytes,7,rep,name=panels,proto3" json:"panels,omitempty"`
+	KeyboardLayouts []*FacetCount      `protobuf:"bytes,8,rep,name=keyboard_layouts,json=keyboardLayouts,proto3" json:"keyboard_layouts,omitempty"`
+	PriceUsd        []*HistogramBucket `protobuf:"bytes,9,rep,name=price_usd,json=priceUsd,proto3" json:"price_usd,omitempty"`
+	WeightKg        []*HistogramBucket `protobuf:"bytes,10,rep,name=weight_kg,json=weightKg,proto3" json:"weight_kg,omitempty"`
+}
+
+func (x *Facets) Reset() {
+	*x = Facets{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_facet_message_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Facets) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Facets) ProtoMessage() {}
+
+func (x *Facets) ProtoReflect() protoreflect.Message {
+	mi := &file_facet_message_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Facets.ProtoReflect.Descriptor instead.
+func (*Facets) Descriptor() ([]byte, []int) {
+	return file_facet_message_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Facets) GetTotal() uint32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *Facets) GetBrands() []*FacetCount {
+	if x != nil {
+		return x.Brands
+	}
+	return nil
+}
+
+func (x *Facets) GetCpuBrands() []*FacetCount {
+	if x != nil {
+		return x.CpuBrands
+	}
+	return nil
+}
+
+func (x *Facets) GetGpuBrands() []*FacetCount {
+	if x != nil {
+		return x.GpuBrands
+	}
+	return nil
+}
+
+func (x *Facets) GetRamGb() []*HistogramBucket {
+	if x != nil {
+		return x.RamGb
+	}
+	return nil
+}
+
+func (x *Facets) GetStorageDrivers() []*FacetCount {
+	if x != nil {
+		return x.StorageDrivers
+	}
+	return nil
+}
+
+func (x *Facets) GetPanels() []*FacetCount {
+	if x != nil {
+		return x.Panels
+	}
+	return nil
+}
+
+func (x *Facets) GetKeyboardLayouts() []*FacetCount {
+	if x != nil {
+		return x.KeyboardLayouts
+	}
+	return nil
+}
+
+func (x *Facets) GetPriceUsd() []*HistogramBucket {
+	if x != nil {
+		return x.PriceUsd
+	}
+	return nil
+}
+
+func (x *Facets) GetWeightKg() []*HistogramBucket {
+	if x != nil {
+		return x.WeightKg
+	}
+	return nil
+}
+
+var File_facet_message_proto protoreflect.FileDescriptor
+
+var file_facet_message_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x66, 0x61, 0x63, 0x65, 0x74, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x22, 0x38, 0x0a, 0x0a, 0x46, 0x61, 0x63,
+	0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0x65, 0x0a, 0x0f, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d,
+	0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x15, 0x0a, 0x03, 0x6d, 0x69, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x01, 0x48, 0x00, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x15, 0x0a,
+	0x03, 0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x48, 0x01, 0x52, 0x03, 0x6d, 0x61,
+	0x78, 0x88, 0x01, 0x01, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0d, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6d,
+	0x69, 0x6e, 0x42, 0x06, 0x0a, 0x04, 0x5f, 0x6d, 0x61, 0x78, 0x22, 0xd0, 0x03, 0x0a, 0x06, 0x46,
+	0x61, 0x63, 0x65, 0x74, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x26, 0x0a, 0x06, 0x62,
+	0x72, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x62,
+	0x2e, 0x46, 0x61, 0x63, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x06, 0x62, 0x72, 0x61,
+	0x6e, 0x64, 0x73, 0x12, 0x2d, 0x0a, 0x0a, 0x63, 0x70, 0x75, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x64,
+	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x61, 0x63,
+	0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x09, 0x63, 0x70, 0x75, 0x42, 0x72, 0x61, 0x6e,
+	0x64, 0x73, 0x12, 0x2d, 0x0a, 0x0a, 0x67, 0x70, 0x75, 0x5f, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x61, 0x63, 0x65,
+	0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x09, 0x67, 0x70, 0x75, 0x42, 0x72, 0x61, 0x6e, 0x64,
+	0x73, 0x12, 0x2a, 0x0a, 0x06, 0x72, 0x61, 0x6d, 0x5f, 0x67, 0x62, 0x18, 0x05, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x13, 0x2e, 0x70, 0x62, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d,
+	0x42, 0x75, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x05, 0x72, 0x61, 0x6d, 0x47, 0x62, 0x12, 0x37, 0x0a,
+	0x0f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x64, 0x72, 0x69, 0x76, 0x65, 0x72, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x61, 0x63, 0x65,
+	0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x44,
+	0x72, 0x69, 0x76, 0x65, 0x72, 0x73, 0x12, 0x26, 0x0a, 0x06, 0x70, 0x61, 0x6e, 0x65, 0x6c, 0x73,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x61, 0x63, 0x65,
+	0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x06, 0x70, 0x61, 0x6e, 0x65, 0x6c, 0x73, 0x12, 0x39,
+	0x0a, 0x10, 0x6b, 0x65, 0x79, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x5f, 0x6c, 0x61, 0x79, 0x6f, 0x75,
+	0x74, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x62, 0x2e, 0x46, 0x61,
+	0x63, 0x65, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x52, 0x0f, 0x6b, 0x65, 0x79, 0x62, 0x6f, 0x61,
+	0x72, 0x64, 0x4c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x73, 0x12, 0x30, 0x0a, 0x09, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x5f, 0x75, 0x73, 0x64, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70,
+	0x62, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x42, 0x75, 0x63, 0x6b, 0x65,
+	0x74, 0x52, 0x08, 0x70, 0x72, 0x69, 0x63, 0x65, 0x55, 0x73, 0x64, 0x12, 0x30, 0x0a, 0x09, 0x77,
+	0x65, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x6b, 0x67, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x70, 0x62, 0x2e, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x67, 0x72, 0x61, 0x6d, 0x42, 0x75, 0x63,
+	0x6b, 0x65, 0x74, 0x52, 0x08, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x4b, 0x67, 0x42, 0x12, 0x5a,
+	0x10, 0x6f, 0x74, 0x6d, 0x61, 0x6e, 0x65, 0x2f, 0x70, 0x63, 0x62, 0x6f, 0x6f, 0x6b, 0x2f, 0x70,
+	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_facet_message_proto_rawDescOnce sync.Once
+	file_facet_message_proto_rawDescData = file_facet_message_proto_rawDesc
+)
+
+func file_facet_message_proto_rawDescGZIP() []byte {
+	file_facet_message_proto_rawDescOnce.Do(func() {
+		file_facet_message_proto_rawDescData = protoimpl.X.CompressGZIP(file_facet_message_proto_rawDescData)
+	})
+	return file_facet_message_proto_rawDescData
+}
+
+var file_facet_message_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_facet_message_proto_goTypes = []interface{}{
+	(*FacetCount)(nil),      // 0: pb.FacetCount
+	(*HistogramBucket)(nil), // 1: pb.HistogramBucket
+	(*Facets)(nil),          // 2: pb.Facets
+}
+var file_facet_message_proto_depIdxs = []int32{
+	0, // 0: pb.Facets.brands:type_name -> pb.FacetCount
+	0, // 1: pb.Facets.cpu_brands:type_name -> pb.FacetCount
+	0, // 2: pb.Facets.gpu_brands:type_name -> pb.FacetCount
+	1, // 3: pb.Facets.ram_gb:type_name -> pb.HistogramBucket
+	0, // 4: pb.Facets.storage_drivers:type_name -> pb.FacetCount
+	0, // 5: pb.Facets.panels:type_name -> pb.FacetCount
+	0, // 6: pb.Facets.keyboard_layouts:type_name -> pb.FacetCount
+	1, // 7: pb.Facets.price_usd:type_name -> pb.HistogramBucket
+	1, // 8: pb.Facets.weight_kg:type_name -> pb.HistogramBucket
+	9, // [9:9] is the sub-list for method output_type
+	9, // [9:9] is the sub-list for method input_type
+	9, // [9:9] is the sub-list for extension type_name
+	9, // [9:9] is the sub-list for extension extendee
+	0, // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_facet_message_proto_init() }
+func file_facet_message_proto_init() {
+	if File_facet_message_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_facet_message_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FacetCount); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_facet_message_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HistogramBucket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_facet_message_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Facets); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_facet_message_proto_msgTypes[1].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_facet_message_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_facet_message_proto_goTypes,
+		DependencyIndexes: file_facet_message_proto_depIdxs,
+		MessageInfos:      file_facet_message_proto_msgTypes,
+	}.Build()
+	File_facet_message_proto = out.File
+	file_facet_message_proto_rawDesc = nil
+	file_facet_message_proto_goTypes = nil
+	file_facet_message_proto_depIdxs = nil
+}
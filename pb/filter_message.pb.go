@@ -20,15 +20,34 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Filter selects the laptops matching all of its criteria. A criterion that
+// is not set, or an empty list, puts no constraint on the laptops.
 type Filter struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	MaxPriceUsd float64 `protobuf:"fixed64,1,opt,name=max_price_usd,json=maxPriceUsd,proto3" json:"max_price_usd,omitempty"`
-	MinCpuCors  uint32  `protobuf:"varint,2,opt,name=min_cpu_cors,json=minCpuCors,proto3" json:"min_cpu_cors,omitempty"`
-	MinCpuGhz   float64 `protobuf:"fixed64,3,opt,name=min_cpu_ghz,json=minCpuGhz,proto3" json:"min_cpu_ghz,omitempty"`
-	MinRam      *Memory `protobuf:"bytes,4,opt,name=min_ram,json=minRam,proto3" json:"min_ram,omitempty"`
+	MaxPriceUsd *float64 `protobuf:"fixed64,1,opt,name=max_price_usd,json=maxPriceUsd,proto3,oneof" json:"max_price_usd,omitempty"`
+	MinCpuCors  *uint32  `protobuf:"varint,2,opt,name=min_cpu_cors,json=minCpuCors,proto3,oneof" json:"min_cpu_cors,omitempty"`
+	MinCpuGhz   *float64 `protobuf:"fixed64,3,opt,name=min_cpu_ghz,json=minCpuGhz,proto3,oneof" json:"min_cpu_ghz,omitempty"`
+	MinRam      *Memory  `protobuf:"bytes,4,opt,name=min_ram,json=minRam,proto3" json:"min_ram,omitempty"`
+	Brands      []string `protobuf:"bytes,5,rep,name=brands,proto3" json:"brands,omitempty"`
+	MinPriceUsd *float64 `protobuf:"fixed64,6,opt,name=min_price_usd,json=minPriceUsd,proto3,oneof" json:"min_price_usd,omitempty"`
+	// A laptop must have one GPU matching both the brands and the memory.
+	GpuBrands         []string           `protobuf:"bytes,7,rep,name=gpu_brands,json=gpuBrands,proto3" json:"gpu_brands,omitempty"`
+	MinGpuMemory      *Memory            `protobuf:"bytes,8,opt,name=min_gpu_memory,json=minGpuMemory,proto3" json:"min_gpu_memory,omitempty"`
+	SsdOnly           bool               `protobuf:"varint,9,opt,name=ssd_only,json=ssdOnly,proto3" json:"ssd_only,omitempty"`
+	MinStorage        *Memory            `protobuf:"bytes,10,opt,name=min_storage,json=minStorage,proto3" json:"min_storage,omitempty"`
+	MinScreenSizeInch *float32           `protobuf:"fixed32,11,opt,name=min_screen_size_inch,json=minScreenSizeInch,proto3,oneof" json:"min_screen_size_inch,omitempty"`
+	MaxScreenSizeInch *float32           `protobuf:"fixed32,12,opt,name=max_screen_size_inch,json=maxScreenSizeInch,proto3,oneof" json:"max_screen_size_inch,omitempty"`
+	MinResolution     *Screen_Resolution `protobuf:"bytes,13,opt,name=min_resolution,json=minResolution,proto3" json:"min_resolution,omitempty"`
+	Panel             *Screen_Panel      `protobuf:"varint,14,opt,name=panel,proto3,enum=pb.Screen_Panel,oneof" json:"panel,omitempty"`
+	KeyboardLayout    *Keyboard_Layout   `protobuf:"varint,15,opt,name=keyboard_layout,json=keyboardLayout,proto3,enum=pb.Keyboard_Layout,oneof" json:"keyboard_layout,omitempty"`
+	Backlit           *bool              `protobuf:"varint,16,opt,name=backlit,proto3,oneof" json:"backlit,omitempty"`
+	MinReleaseYear    *uint32            `protobuf:"varint,17,opt,name=min_release_year,json=minReleaseYear,proto3,oneof" json:"min_release_year,omitempty"`
+	MaxReleaseYear    *uint32            `protobuf:"varint,18,opt,name=max_release_year,json=maxReleaseYear,proto3,oneof" json:"max_release_year,omitempty"`
+	// Laptops weighted in pounds are converted to kilograms.
+	MaxWeightKg *float64 `protobuf:"fixed64,19,opt,name=max_weight_kg,json=maxWeightKg,proto3,oneof" json:"max_weight_kg,omitempty"`
 }
 
 func (x *Filter) Reset() {
@@ -64,22 +83,22 @@ func (*Filter) Descriptor() ([]byte, []int) {
 }
 
 func (x *Filter) GetMaxPriceUsd() float64 {
-	if x != nil {
-		return x.MaxPriceUsd
+	if x != nil && x.MaxPriceUsd != nil {
+		return *x.MaxPriceUsd
 	}
 	return 0
 }
 
 func (x *Filter) GetMinCpuCors() uint32 {
-	if x != nil {
-		return x.MinCpuCors
+	if x != nil && x.MinCpuCors != nil {
+		return *x.MinCpuCors
 	}
 	return 0
 }
 
 func (x *Filter) GetMinCpuGhz() float64 {
-	if x != nil {
-		return x.MinCpuGhz
+	if x != nil && x.MinCpuGhz != nil {
+		return *x.MinCpuGhz
 	}
 	return 0
 }
@@ -91,24 +110,188 @@ func (x *Filter) GetMinRam() *Memory {
 	return nil
 }
 
+func (x *Filter) GetBrands() []string {
+	if x != nil {
+		return x.Brands
+	}
+	return nil
+}
+
+func (x *Filter) GetMinPriceUsd() float64 {
+	if x != nil && x.MinPriceUsd != nil {
+		return *x.MinPriceUsd
+	}
+	return 0
+}
+
+func (x *Filter) GetGpuBrands() []string {
+	if x != nil {
+		return x.GpuBrands
+	}
+	return nil
+}
+
+func (x *Filter) GetMinGpuMemory() *Memory {
+	if x != nil {
+		return x.MinGpuMemory
+	}
+	return nil
+}
+
+func (x *Filter) GetSsdOnly() bool {
+	if x != nil {
+		return x.SsdOnly
+	}
+	return false
+}
+
+func (x *Filter) GetMinStorage() *Memory {
+	if x != nil {
+		return x.MinStorage
+	}
+	return nil
+}
+
+func (x *Filter) GetMinScreenSizeInch() float32 {
+	if x != nil && x.MinScreenSizeInch != nil {
+		return *x.MinScreenSizeInch
+	}
+	return 0
+}
+
+func (x *Filter) GetMaxScreenSizeInch() float32 {
+	if x != nil && x.MaxScreenSizeInch != nil {
+		return *x.MaxScreenSizeInch
+	}
+	return 0
+}
+
+func (x *Filter) GetMinResolution() *Screen_Resolution {
+	if x != nil {
+		return x.MinResolution
+	}
+	return nil
+}
+
+func (x *Filter) GetPanel() Screen_Panel {
+	if x != nil && x.Panel != nil {
+		return *x.Panel
+	}
+	return Screen_UNKNOWN
+}
+
+func (x *Filter) GetKeyboardLayout() Keyboard_Layout {
+	if x != nil && x.KeyboardLayout != nil {
+		return *x.KeyboardLayout
+	}
+	return Keyboard_UNKNOWN
+}
+
+func (x *Filter) GetBacklit() bool {
+	if x != nil && x.Backlit != nil {
+		return *x.Backlit
+	}
+	return false
+}
+
+func (x *Filter) GetMinReleaseYear() uint32 {
+	if x != nil && x.MinReleaseYear != nil {
+		return *x.MinReleaseYear
+	}
+	return 0
+}
+
+func (x *Filter) GetMaxReleaseYear() uint32 {
+	if x != nil && x.MaxReleaseYear != nil {
+		return *x.MaxReleaseYear
+	}
+	return 0
+}
+
+func (x *Filter) GetMaxWeightKg() float64 {
+	if x != nil && x.MaxWeightKg != nil {
+		return *x.MaxWeightKg
+	}
+	return 0
+}
+
 var File_filter_message_proto protoreflect.FileDescriptor
 
 var file_filter_message_proto_rawDesc = []byte{
 	0x0a, 0x14, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
 	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x14, 0x6d, 0x65, 0x6d, 0x6f,
 	0x72, 0x79, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x22, 0x93, 0x01, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x22, 0x0a, 0x0d, 0x6d,
-	0x61, 0x78, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x75, 0x73, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x01, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x69, 0x63, 0x65, 0x55, 0x73, 0x64, 0x12,
-	0x20, 0x0a, 0x0c, 0x6d, 0x69, 0x6e, 0x5f, 0x63, 0x70, 0x75, 0x5f, 0x63, 0x6f, 0x72, 0x73, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x43, 0x70, 0x75, 0x43, 0x6f, 0x72,
-	0x73, 0x12, 0x1e, 0x0a, 0x0b, 0x6d, 0x69, 0x6e, 0x5f, 0x63, 0x70, 0x75, 0x5f, 0x67, 0x68, 0x7a,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x43, 0x70, 0x75, 0x47, 0x68,
-	0x7a, 0x12, 0x23, 0x0a, 0x07, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x61, 0x6d, 0x18, 0x04, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x70, 0x62, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x52, 0x06,
-	0x6d, 0x69, 0x6e, 0x52, 0x61, 0x6d, 0x42, 0x12, 0x5a, 0x10, 0x6f, 0x74, 0x6d, 0x61, 0x6e, 0x65,
-	0x2f, 0x70, 0x63, 0x62, 0x6f, 0x6f, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x33,
+	0x1a, 0x14, 0x73, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x16, 0x6b, 0x65, 0x79, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x99,
+	0x08, 0x0a, 0x06, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x27, 0x0a, 0x0d, 0x6d, 0x61, 0x78,
+	0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x75, 0x73, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01,
+	0x48, 0x00, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x50, 0x72, 0x69, 0x63, 0x65, 0x55, 0x73, 0x64, 0x88,
+	0x01, 0x01, 0x12, 0x25, 0x0a, 0x0c, 0x6d, 0x69, 0x6e, 0x5f, 0x63, 0x70, 0x75, 0x5f, 0x63, 0x6f,
+	0x72, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x48, 0x01, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x43,
+	0x70, 0x75, 0x43, 0x6f, 0x72, 0x73, 0x88, 0x01, 0x01, 0x12, 0x23, 0x0a, 0x0b, 0x6d, 0x69, 0x6e,
+	0x5f, 0x63, 0x70, 0x75, 0x5f, 0x67, 0x68, 0x7a, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x48, 0x02,
+	0x52, 0x09, 0x6d, 0x69, 0x6e, 0x43, 0x70, 0x75, 0x47, 0x68, 0x7a, 0x88, 0x01, 0x01, 0x12, 0x23,
+	0x0a, 0x07, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x61, 0x6d, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0a, 0x2e, 0x70, 0x62, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x52, 0x06, 0x6d, 0x69, 0x6e,
+	0x52, 0x61, 0x6d, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e, 0x64, 0x73, 0x12, 0x27, 0x0a, 0x0d, 0x6d,
+	0x69, 0x6e, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x75, 0x73, 0x64, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x01, 0x48, 0x03, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x55, 0x73,
+	0x64, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x70, 0x75, 0x5f, 0x62, 0x72, 0x61, 0x6e,
+	0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x67, 0x70, 0x75, 0x42, 0x72, 0x61,
+	0x6e, 0x64, 0x73, 0x12, 0x30, 0x0a, 0x0e, 0x6d, 0x69, 0x6e, 0x5f, 0x67, 0x70, 0x75, 0x5f, 0x6d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x70, 0x62,
+	0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x52, 0x0c, 0x6d, 0x69, 0x6e, 0x47, 0x70, 0x75, 0x4d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x73, 0x64, 0x5f, 0x6f, 0x6e, 0x6c,
+	0x79, 0x18, 0x09, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x73, 0x64, 0x4f, 0x6e, 0x6c, 0x79,
+	0x12, 0x2b, 0x0a, 0x0b, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e, 0x70, 0x62, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72,
+	0x79, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x12, 0x34, 0x0a,
+	0x14, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65,
+	0x5f, 0x69, 0x6e, 0x63, 0x68, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x02, 0x48, 0x04, 0x52, 0x11, 0x6d,
+	0x69, 0x6e, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x53, 0x69, 0x7a, 0x65, 0x49, 0x6e, 0x63, 0x68,
+	0x88, 0x01, 0x01, 0x12, 0x34, 0x0a, 0x14, 0x6d, 0x61, 0x78, 0x5f, 0x73, 0x63, 0x72, 0x65, 0x65,
+	0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x69, 0x6e, 0x63, 0x68, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x02, 0x48, 0x05, 0x52, 0x11, 0x6d, 0x61, 0x78, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x53, 0x69,
+	0x7a, 0x65, 0x49, 0x6e, 0x63, 0x68, 0x88, 0x01, 0x01, 0x12, 0x3c, 0x0a, 0x0e, 0x6d, 0x69, 0x6e,
+	0x5f, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x15, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x2e, 0x52, 0x65,
+	0x73, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x73,
+	0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x05, 0x70, 0x61, 0x6e, 0x65, 0x6c,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x63, 0x72, 0x65,
+	0x65, 0x6e, 0x2e, 0x50, 0x61, 0x6e, 0x65, 0x6c, 0x48, 0x06, 0x52, 0x05, 0x70, 0x61, 0x6e, 0x65,
+	0x6c, 0x88, 0x01, 0x01, 0x12, 0x41, 0x0a, 0x0f, 0x6b, 0x65, 0x79, 0x62, 0x6f, 0x61, 0x72, 0x64,
+	0x5f, 0x6c, 0x61, 0x79, 0x6f, 0x75, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13, 0x2e,
+	0x70, 0x62, 0x2e, 0x4b, 0x65, 0x79, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x2e, 0x4c, 0x61, 0x79, 0x6f,
+	0x75, 0x74, 0x48, 0x07, 0x52, 0x0e, 0x6b, 0x65, 0x79, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x4c, 0x61,
+	0x79, 0x6f, 0x75, 0x74, 0x88, 0x01, 0x01, 0x12, 0x1d, 0x0a, 0x07, 0x62, 0x61, 0x63, 0x6b, 0x6c,
+	0x69, 0x74, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08, 0x48, 0x08, 0x52, 0x07, 0x62, 0x61, 0x63, 0x6b,
+	0x6c, 0x69, 0x74, 0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x10, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x65,
+	0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x79, 0x65, 0x61, 0x72, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0d,
+	0x48, 0x09, 0x52, 0x0e, 0x6d, 0x69, 0x6e, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x59, 0x65,
+	0x61, 0x72, 0x88, 0x01, 0x01, 0x12, 0x2d, 0x0a, 0x10, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x6c,
+	0x65, 0x61, 0x73, 0x65, 0x5f, 0x79, 0x65, 0x61, 0x72, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0d, 0x48,
+	0x0a, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x59, 0x65, 0x61,
+	0x72, 0x88, 0x01, 0x01, 0x12, 0x27, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x77, 0x65, 0x69, 0x67,
+	0x68, 0x74, 0x5f, 0x6b, 0x67, 0x18, 0x13, 0x20, 0x01, 0x28, 0x01, 0x48, 0x0b, 0x52, 0x0b, 0x6d,
+	0x61, 0x78, 0x57, 0x65, 0x69, 0x67, 0x68, 0x74, 0x4b, 0x67, 0x88, 0x01, 0x01, 0x42, 0x10, 0x0a,
+	0x0e, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x75, 0x73, 0x64, 0x42,
+	0x0f, 0x0a, 0x0d, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x63, 0x70, 0x75, 0x5f, 0x63, 0x6f, 0x72, 0x73,
+	0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x63, 0x70, 0x75, 0x5f, 0x67, 0x68, 0x7a,
+	0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x5f, 0x75,
+	0x73, 0x64, 0x42, 0x17, 0x0a, 0x15, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x73, 0x63, 0x72, 0x65, 0x65,
+	0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f, 0x69, 0x6e, 0x63, 0x68, 0x42, 0x17, 0x0a, 0x15, 0x5f,
+	0x6d, 0x61, 0x78, 0x5f, 0x73, 0x63, 0x72, 0x65, 0x65, 0x6e, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x5f,
+	0x69, 0x6e, 0x63, 0x68, 0x42, 0x08, 0x0a, 0x06, 0x5f, 0x70, 0x61, 0x6e, 0x65, 0x6c, 0x42, 0x12,
+	0x0a, 0x10, 0x5f, 0x6b, 0x65, 0x79, 0x62, 0x6f, 0x61, 0x72, 0x64, 0x5f, 0x6c, 0x61, 0x79, 0x6f,
+	0x75, 0x74, 0x42, 0x0a, 0x0a, 0x08, 0x5f, 0x62, 0x61, 0x63, 0x6b, 0x6c, 0x69, 0x74, 0x42, 0x13,
+	0x0a, 0x11, 0x5f, 0x6d, 0x69, 0x6e, 0x5f, 0x72, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x5f, 0x79,
+	0x65, 0x61, 0x72, 0x42, 0x13, 0x0a, 0x11, 0x5f, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x6c, 0x65,
+	0x61, 0x73, 0x65, 0x5f, 0x79, 0x65, 0x61, 0x72, 0x42, 0x10, 0x0a, 0x0e, 0x5f, 0x6d, 0x61, 0x78,
+	0x5f, 0x77, 0x65, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x6b, 0x67, 0x42, 0x12, 0x5a, 0x10, 0x6f, 0x74,
+	0x6d, 0x61, 0x6e, 0x65, 0x2f, 0x70, 0x63, 0x62, 0x6f, 0x6f, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -125,16 +308,24 @@ func file_filter_message_proto_rawDescGZIP() []byte {
 
 var file_filter_message_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
 var file_filter_message_proto_goTypes = []interface{}{
-	(*Filter)(nil), // 0: pb.Filter
-	(*Memory)(nil), // 1: pb.Memory
+	(*Filter)(nil),            // 0: pb.Filter
+	(*Memory)(nil),            // 1: pb.Memory
+	(*Screen_Resolution)(nil), // 2: pb.Screen.Resolution
+	(Screen_Panel)(0),         // 3: pb.Screen.Panel
+	(Keyboard_Layout)(0),      // 4: pb.Keyboard.Layout
 }
 var file_filter_message_proto_depIdxs = []int32{
 	1, // 0: pb.Filter.min_ram:type_name -> pb.Memory
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	1, // 1: pb.Filter.min_gpu_memory:type_name -> pb.Memory
+	1, // 2: pb.Filter.min_storage:type_name -> pb.Memory
+	2, // 3: pb.Filter.min_resolution:type_name -> pb.Screen.Resolution
+	3, // 4: pb.Filter.panel:type_name -> pb.Screen.Panel
+	4, // 5: pb.Filter.keyboard_layout:type_name -> pb.Keyboard.Layout
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_filter_message_proto_init() }
@@ -143,6 +334,8 @@ func file_filter_message_proto_init() {
 		return
 	}
 	file_memory_message_proto_init()
+	file_screen_message_proto_init()
+	file_keyboard_message_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_filter_message_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Filter); i {
@@ -157,6 +350,7 @@ func file_filter_message_proto_init() {
 			}
 		}
 	}
+	file_filter_message_proto_msgTypes[0].OneofWrappers = []interface{}{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
@@ -0,0 +1,319 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.12.4
+// source: image_message.proto
+
+package pb
+
+import (
+	timestamp "github.com/golang/protobuf/ptypes/timestamp"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// ImageRecord is the metadata of an image of a disk image store, saved in its index file
+type ImageRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	LaptopId  string `protobuf:"bytes,2,opt,name=laptop_id,json=laptopId,proto3" json:"laptop_id,omitempty"`
+	ImageType string `protobuf:"bytes,3,opt,name=image_type,json=imageType,proto3" json:"image_type,omitempty"`
+	// Name of the image file in the image folder.
+	FileName string `protobuf:"bytes,4,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	Size     uint64 `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	// Hex encoded SHA-256 checksum of the image data.
+	ChecksumSha256 string               `protobuf:"bytes,6,opt,name=checksum_sha256,json=checksumSha256,proto3" json:"checksum_sha256,omitempty"`
+	CreatedAt      *timestamp.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+}
+
+func (x *ImageRecord) Reset() {
+	*x = ImageRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_image_message_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImageRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageRecord) ProtoMessage() {}
+
+func (x *ImageRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_image_message_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageRecord.ProtoReflect.Descriptor instead.
+func (*ImageRecord) Descriptor() ([]byte, []int) {
+	return file_image_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ImageRecord) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ImageRecord) GetLaptopId() string {
+	if x != nil {
+		return x.LaptopId
+	}
+	return ""
+}
+
+func (x *ImageRecord) GetImageType() string {
+	if x != nil {
+		return x.ImageType
+	}
+	return ""
+}
+
+func (x *ImageRecord) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *ImageRecord) GetSize() uint64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ImageRecord) GetChecksumSha256() string {
+	if x != nil {
+		return x.ChecksumSha256
+	}
+	return ""
+}
+
+func (x *ImageRecord) GetCreatedAt() *timestamp.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// ImageIndexRecord is a change of the index of a disk image store, appended to its index file
+type ImageIndexRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Change:
+	//
+	//	*ImageIndexRecord_Put
+	//	*ImageIndexRecord_DeleteId
+	Change isImageIndexRecord_Change `protobuf_oneof:"change"`
+}
+
+func (x *ImageIndexRecord) Reset() {
+	*x = ImageIndexRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_image_message_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ImageIndexRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImageIndexRecord) ProtoMessage() {}
+
+func (x *ImageIndexRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_image_message_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImageIndexRecord.ProtoReflect.Descriptor instead.
+func (*ImageIndexRecord) Descriptor() ([]byte, []int) {
+	return file_image_message_proto_rawDescGZIP(), []int{1}
+}
+
+func (m *ImageIndexRecord) GetChange() isImageIndexRecord_Change {
+	if m != nil {
+		return m.Change
+	}
+	return nil
+}
+
+func (x *ImageIndexRecord) GetPut() *ImageRecord {
+	if x, ok := x.GetChange().(*ImageIndexRecord_Put); ok {
+		return x.Put
+	}
+	return nil
+}
+
+func (x *ImageIndexRecord) GetDeleteId() string {
+	if x, ok := x.GetChange().(*ImageIndexRecord_DeleteId); ok {
+		return x.DeleteId
+	}
+	return ""
+}
+
+type isImageIndexRecord_Change interface {
+	isImageIndexRecord_Change()
+}
+
+type ImageIndexRecord_Put struct {
+	// put saves the image, replacing the one with the same ID if any
+	Put *ImageRecord `protobuf:"bytes,1,opt,name=put,proto3,oneof"`
+}
+
+type ImageIndexRecord_DeleteId struct {
+	// delete_id removes the image with this ID
+	DeleteId string `protobuf:"bytes,2,opt,name=delete_id,json=deleteId,proto3,oneof"`
+}
+
+func (*ImageIndexRecord_Put) isImageIndexRecord_Change() {}
+
+func (*ImageIndexRecord_DeleteId) isImageIndexRecord_Change() {}
+
+var File_image_message_proto protoreflect.FileDescriptor
+
+var file_image_message_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xee, 0x01, 0x0a, 0x0b, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6c, 0x61,
+	0x70, 0x74, 0x6f, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c,
+	0x61, 0x70, 0x74, 0x6f, 0x70, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6d, 0x61, 0x67, 0x65,
+	0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x6d, 0x61,
+	0x67, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x73, 0x75, 0x6d, 0x5f, 0x73, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x53, 0x68, 0x61, 0x32, 0x35, 0x36,
+	0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x07,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x60, 0x0a, 0x10, 0x49,
+	0x6d, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x12,
+	0x23, 0x0a, 0x03, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x70,
+	0x62, 0x2e, 0x49, 0x6d, 0x61, 0x67, 0x65, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x48, 0x00, 0x52,
+	0x03, 0x70, 0x75, 0x74, 0x12, 0x1d, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x64, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x49, 0x64, 0x42, 0x08, 0x0a, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x12, 0x5a,
+	0x10, 0x6f, 0x74, 0x6d, 0x61, 0x6e, 0x65, 0x2f, 0x70, 0x63, 0x62, 0x6f, 0x6f, 0x6b, 0x2f, 0x70,
+	0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_image_message_proto_rawDescOnce sync.Once
+	file_image_message_proto_rawDescData = file_image_message_proto_rawDesc
+)
+
+func file_image_message_proto_rawDescGZIP() []byte {
+	file_image_message_proto_rawDescOnce.Do(func() {
+		file_image_message_proto_rawDescData = protoimpl.X.CompressGZIP(file_image_message_proto_rawDescData)
+	})
+	return file_image_message_proto_rawDescData
+}
+
+var file_image_message_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_image_message_proto_goTypes = []interface{}{
+	(*ImageRecord)(nil),         // 0: pb.ImageRecord
+	(*ImageIndexRecord)(nil),    // 1: pb.ImageIndexRecord
+	(*timestamp.Timestamp)(nil), // 2: google.protobuf.Timestamp
+}
+var file_image_message_proto_depIdxs = []int32{
+	2, // 0: pb.ImageRecord.created_at:type_name -> google.protobuf.Timestamp
+	0, // 1: pb.ImageIndexRecord.put:type_name -> pb.ImageRecord
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_image_message_proto_init() }
+func file_image_message_proto_init() {
+	if File_image_message_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_image_message_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImageRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_image_message_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ImageIndexRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_image_message_proto_msgTypes[1].OneofWrappers = []interface{}{
+		(*ImageIndexRecord_Put)(nil),
+		(*ImageIndexRecord_DeleteId)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_image_message_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_image_message_proto_goTypes,
+		DependencyIndexes: file_image_message_proto_depIdxs,
+		MessageInfos:      file_image_message_proto_msgTypes,
+	}.Build()
+	File_image_message_proto = out.File
+	file_image_message_proto_rawDesc = nil
+	file_image_message_proto_goTypes = nil
+	file_image_message_proto_depIdxs = nil
+}
@@ -23,8 +23,15 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type LaptopServiceClient interface {
 	CreateLaptop(ctx context.Context, in *CreateLaptopRequest, opts ...grpc.CallOption) (*CreateLaptopResponse, error)
+	GetLaptop(ctx context.Context, in *GetLaptopRequest, opts ...grpc.CallOption) (*GetLaptopResponse, error)
+	UpdateLaptop(ctx context.Context, in *UpdateLaptopRequest, opts ...grpc.CallOption) (*UpdateLaptopResponse, error)
+	DeleteLaptop(ctx context.Context, in *DeleteLaptopRequest, opts ...grpc.CallOption) (*DeleteLaptopResponse, error)
 	SearchLaptop(ctx context.Context, in *SearchLaptopRequest, opts ...grpc.CallOption) (LaptopService_SearchLaptopClient, error)
+	FacetLaptops(ctx context.Context, in *FacetLaptopsRequest, opts ...grpc.CallOption) (*FacetLaptopsResponse, error)
 	UploadImage(ctx context.Context, opts ...grpc.CallOption) (LaptopService_UploadImageClient, error)
+	DownloadImage(ctx context.Context, in *DownloadImageRequest, opts ...grpc.CallOption) (LaptopService_DownloadImageClient, error)
+	ListLaptopImages(ctx context.Context, in *ListLaptopImagesRequest, opts ...grpc.CallOption) (*ListLaptopImagesResponse, error)
+	DeleteImage(ctx context.Context, in *DeleteImageRequest, opts ...grpc.CallOption) (*DeleteImageResponse, error)
 	RateLaptop(ctx context.Context, opts ...grpc.CallOption) (LaptopService_RateLaptopClient, error)
 }
 
@@ -45,6 +52,33 @@ func (c *laptopServiceClient) CreateLaptop(ctx context.Context, in *CreateLaptop
 	return out, nil
 }
 
+func (c *laptopServiceClient) GetLaptop(ctx context.Context, in *GetLaptopRequest, opts ...grpc.CallOption) (*GetLaptopResponse, error) {
+	out := new(GetLaptopResponse)
+	err := c.cc.Invoke(ctx, "/pb.LaptopService/GetLaptop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *laptopServiceClient) UpdateLaptop(ctx context.Context, in *UpdateLaptopRequest, opts ...grpc.CallOption) (*UpdateLaptopResponse, error) {
+	out := new(UpdateLaptopResponse)
+	err := c.cc.Invoke(ctx, "/pb.LaptopService/UpdateLaptop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *laptopServiceClient) DeleteLaptop(ctx context.Context, in *DeleteLaptopRequest, opts ...grpc.CallOption) (*DeleteLaptopResponse, error) {
+	out := new(DeleteLaptopResponse)
+	err := c.cc.Invoke(ctx, "/pb.LaptopService/DeleteLaptop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *laptopServiceClient) SearchLaptop(ctx context.Context, in *SearchLaptopRequest, opts ...grpc.CallOption) (LaptopService_SearchLaptopClient, error) {
 	stream, err := c.cc.NewStream(ctx, &LaptopService_ServiceDesc.Streams[0], "/pb.LaptopService/SearchLaptop", opts...)
 	if err != nil {
@@ -77,6 +111,15 @@ func (x *laptopServiceSearchLaptopClient) Recv() (*SearchLaptopResponse, error)
 	return m, nil
 }
 
+func (c *laptopServiceClient) FacetLaptops(ctx context.Context, in *FacetLaptopsRequest, opts ...grpc.CallOption) (*FacetLaptopsResponse, error) {
+	out := new(FacetLaptopsResponse)
+	err := c.cc.Invoke(ctx, "/pb.LaptopService/FacetLaptops", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *laptopServiceClient) UploadImage(ctx context.Context, opts ...grpc.CallOption) (LaptopService_UploadImageClient, error) {
 	stream, err := c.cc.NewStream(ctx, &LaptopService_ServiceDesc.Streams[1], "/pb.LaptopService/UploadImage", opts...)
 	if err != nil {
@@ -111,8 +154,58 @@ func (x *laptopServiceUploadImageClient) CloseAndRecv() (*UploadImageResponse, e
 	return m, nil
 }
 
+func (c *laptopServiceClient) DownloadImage(ctx context.Context, in *DownloadImageRequest, opts ...grpc.CallOption) (LaptopService_DownloadImageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &LaptopService_ServiceDesc.Streams[2], "/pb.LaptopService/DownloadImage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &laptopServiceDownloadImageClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type LaptopService_DownloadImageClient interface {
+	Recv() (*DownloadImageResponse, error)
+	grpc.ClientStream
+}
+
+type laptopServiceDownloadImageClient struct {
+	grpc.ClientStream
+}
+
+func (x *laptopServiceDownloadImageClient) Recv() (*DownloadImageResponse, error) {
+	m := new(DownloadImageResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *laptopServiceClient) ListLaptopImages(ctx context.Context, in *ListLaptopImagesRequest, opts ...grpc.CallOption) (*ListLaptopImagesResponse, error) {
+	out := new(ListLaptopImagesResponse)
+	err := c.cc.Invoke(ctx, "/pb.LaptopService/ListLaptopImages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *laptopServiceClient) DeleteImage(ctx context.Context, in *DeleteImageRequest, opts ...grpc.CallOption) (*DeleteImageResponse, error) {
+	out := new(DeleteImageResponse)
+	err := c.cc.Invoke(ctx, "/pb.LaptopService/DeleteImage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *laptopServiceClient) RateLaptop(ctx context.Context, opts ...grpc.CallOption) (LaptopService_RateLaptopClient, error) {
-	stream, err := c.cc.NewStream(ctx, &LaptopService_ServiceDesc.Streams[2], "/pb.LaptopService/RateLaptop", opts...)
+	stream, err := c.cc.NewStream(ctx, &LaptopService_ServiceDesc.Streams[3], "/pb.LaptopService/RateLaptop", opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -147,8 +240,15 @@ func (x *laptopServiceRateLaptopClient) Recv() (*RateLaptopResponse, error) {
 // for forward compatibility
 type LaptopServiceServer interface {
 	CreateLaptop(context.Context, *CreateLaptopRequest) (*CreateLaptopResponse, error)
+	GetLaptop(context.Context, *GetLaptopRequest) (*GetLaptopResponse, error)
+	UpdateLaptop(context.Context, *UpdateLaptopRequest) (*UpdateLaptopResponse, error)
+	DeleteLaptop(context.Context, *DeleteLaptopRequest) (*DeleteLaptopResponse, error)
 	SearchLaptop(*SearchLaptopRequest, LaptopService_SearchLaptopServer) error
+	FacetLaptops(context.Context, *FacetLaptopsRequest) (*FacetLaptopsResponse, error)
 	UploadImage(LaptopService_UploadImageServer) error
+	DownloadImage(*DownloadImageRequest, LaptopService_DownloadImageServer) error
+	ListLaptopImages(context.Context, *ListLaptopImagesRequest) (*ListLaptopImagesResponse, error)
+	DeleteImage(context.Context, *DeleteImageRequest) (*DeleteImageResponse, error)
 	RateLaptop(LaptopService_RateLaptopServer) error
 	mustEmbedUnimplementedLaptopServiceServer()
 }
@@ -160,12 +260,33 @@ type UnimplementedLaptopServiceServer struct {
 func (UnimplementedLaptopServiceServer) CreateLaptop(context.Context, *CreateLaptopRequest) (*CreateLaptopResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateLaptop not implemented")
 }
+func (UnimplementedLaptopServiceServer) GetLaptop(context.Context, *GetLaptopRequest) (*GetLaptopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLaptop not implemented")
+}
+func (UnimplementedLaptopServiceServer) UpdateLaptop(context.Context, *UpdateLaptopRequest) (*UpdateLaptopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateLaptop not implemented")
+}
+func (UnimplementedLaptopServiceServer) DeleteLaptop(context.Context, *DeleteLaptopRequest) (*DeleteLaptopResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteLaptop not implemented")
+}
 func (UnimplementedLaptopServiceServer) SearchLaptop(*SearchLaptopRequest, LaptopService_SearchLaptopServer) error {
 	return status.Errorf(codes.Unimplemented, "method SearchLaptop not implemented")
 }
+func (UnimplementedLaptopServiceServer) FacetLaptops(context.Context, *FacetLaptopsRequest) (*FacetLaptopsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FacetLaptops not implemented")
+}
 func (UnimplementedLaptopServiceServer) UploadImage(LaptopService_UploadImageServer) error {
 	return status.Errorf(codes.Unimplemented, "method UploadImage not implemented")
 }
+func (UnimplementedLaptopServiceServer) DownloadImage(*DownloadImageRequest, LaptopService_DownloadImageServer) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadImage not implemented")
+}
+func (UnimplementedLaptopServiceServer) ListLaptopImages(context.Context, *ListLaptopImagesRequest) (*ListLaptopImagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListLaptopImages not implemented")
+}
+func (UnimplementedLaptopServiceServer) DeleteImage(context.Context, *DeleteImageRequest) (*DeleteImageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteImage not implemented")
+}
 func (UnimplementedLaptopServiceServer) RateLaptop(LaptopService_RateLaptopServer) error {
 	return status.Errorf(codes.Unimplemented, "method RateLaptop not implemented")
 }
@@ -200,6 +321,60 @@ func _LaptopService_CreateLaptop_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LaptopService_GetLaptop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLaptopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaptopServiceServer).GetLaptop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.LaptopService/GetLaptop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaptopServiceServer).GetLaptop(ctx, req.(*GetLaptopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LaptopService_UpdateLaptop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateLaptopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaptopServiceServer).UpdateLaptop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.LaptopService/UpdateLaptop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaptopServiceServer).UpdateLaptop(ctx, req.(*UpdateLaptopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LaptopService_DeleteLaptop_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteLaptopRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaptopServiceServer).DeleteLaptop(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.LaptopService/DeleteLaptop",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaptopServiceServer).DeleteLaptop(ctx, req.(*DeleteLaptopRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LaptopService_SearchLaptop_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SearchLaptopRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -221,6 +396,24 @@ func (x *laptopServiceSearchLaptopServer) Send(m *SearchLaptopResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _LaptopService_FacetLaptops_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FacetLaptopsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaptopServiceServer).FacetLaptops(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.LaptopService/FacetLaptops",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaptopServiceServer).FacetLaptops(ctx, req.(*FacetLaptopsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LaptopService_UploadImage_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(LaptopServiceServer).UploadImage(&laptopServiceUploadImageServer{stream})
 }
@@ -247,6 +440,63 @@ func (x *laptopServiceUploadImageServer) Recv() (*UploadImageRequest, error) {
 	return m, nil
 }
 
+func _LaptopService_DownloadImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadImageRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LaptopServiceServer).DownloadImage(m, &laptopServiceDownloadImageServer{stream})
+}
+
+type LaptopService_DownloadImageServer interface {
+	Send(*DownloadImageResponse) error
+	grpc.ServerStream
+}
+
+type laptopServiceDownloadImageServer struct {
+	grpc.ServerStream
+}
+
+func (x *laptopServiceDownloadImageServer) Send(m *DownloadImageResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _LaptopService_ListLaptopImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLaptopImagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaptopServiceServer).ListLaptopImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.LaptopService/ListLaptopImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaptopServiceServer).ListLaptopImages(ctx, req.(*ListLaptopImagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LaptopService_DeleteImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteImageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LaptopServiceServer).DeleteImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pb.LaptopService/DeleteImage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LaptopServiceServer).DeleteImage(ctx, req.(*DeleteImageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _LaptopService_RateLaptop_Handler(srv interface{}, stream grpc.ServerStream) error {
 	return srv.(LaptopServiceServer).RateLaptop(&laptopServiceRateLaptopServer{stream})
 }
@@ -284,6 +534,30 @@ var LaptopService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateLaptop",
 			Handler:    _LaptopService_CreateLaptop_Handler,
 		},
+		{
+			MethodName: "GetLaptop",
+			Handler:    _LaptopService_GetLaptop_Handler,
+		},
+		{
+			MethodName: "UpdateLaptop",
+			Handler:    _LaptopService_UpdateLaptop_Handler,
+		},
+		{
+			MethodName: "DeleteLaptop",
+			Handler:    _LaptopService_DeleteLaptop_Handler,
+		},
+		{
+			MethodName: "FacetLaptops",
+			Handler:    _LaptopService_FacetLaptops_Handler,
+		},
+		{
+			MethodName: "ListLaptopImages",
+			Handler:    _LaptopService_ListLaptopImages_Handler,
+		},
+		{
+			MethodName: "DeleteImage",
+			Handler:    _LaptopService_DeleteImage_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -296,6 +570,11 @@ var LaptopService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _LaptopService_UploadImage_Handler,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "DownloadImage",
+			Handler:       _LaptopService_DownloadImage_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "RateLaptop",
 			Handler:       _LaptopService_RateLaptop_Handler,
@@ -0,0 +1,191 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.12.4
+// source: record_message.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// LaptopRecord is a change of a durable laptop store, appended to its log
+type LaptopRecord struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Change:
+	//
+	//	*LaptopRecord_Put
+	//	*LaptopRecord_DeleteId
+	Change isLaptopRecord_Change `protobuf_oneof:"change"`
+}
+
+func (x *LaptopRecord) Reset() {
+	*x = LaptopRecord{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_record_message_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LaptopRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LaptopRecord) ProtoMessage() {}
+
+func (x *LaptopRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_record_message_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LaptopRecord.ProtoReflect.Descriptor instead.
+func (*LaptopRecord) Descriptor() ([]byte, []int) {
+	return file_record_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *LaptopRecord) GetChange() isLaptopRecord_Change {
+	if m != nil {
+		return m.Change
+	}
+	return nil
+}
+
+func (x *LaptopRecord) GetPut() *Laptop {
+	if x, ok := x.GetChange().(*LaptopRecord_Put); ok {
+		return x.Put
+	}
+	return nil
+}
+
+func (x *LaptopRecord) GetDeleteId() string {
+	if x, ok := x.GetChange().(*LaptopRecord_DeleteId); ok {
+		return x.DeleteId
+	}
+	return ""
+}
+
+type isLaptopRecord_Change interface {
+	isLaptopRecord_Change()
+}
+
+type LaptopRecord_Put struct {
+	// put saves the laptop, replacing the one with the same ID if any
+	Put *Laptop `protobuf:"bytes,1,opt,name=put,proto3,oneof"`
+}
+
+type LaptopRecord_DeleteId struct {
+	// delete_id removes the laptop with this ID
+	DeleteId string `protobuf:"bytes,2,opt,name=delete_id,json=deleteId,proto3,oneof"`
+}
+
+func (*LaptopRecord_Put) isLaptopRecord_Change() {}
+
+func (*LaptopRecord_DeleteId) isLaptopRecord_Change() {}
+
+var File_record_message_proto protoreflect.FileDescriptor
+
+var file_record_message_proto_rawDesc = []byte{
+	0x0a, 0x14, 0x72, 0x65, 0x63, 0x6f, 0x72, 0x64, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x1a, 0x14, 0x6c, 0x61, 0x70, 0x74,
+	0x6f, 0x70, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x22, 0x57, 0x0a, 0x0c, 0x4c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x52, 0x65, 0x63, 0x6f, 0x72, 0x64,
+	0x12, 0x1e, 0x0a, 0x03, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0a, 0x2e,
+	0x70, 0x62, 0x2e, 0x4c, 0x61, 0x70, 0x74, 0x6f, 0x70, 0x48, 0x00, 0x52, 0x03, 0x70, 0x75, 0x74,
+	0x12, 0x1d, 0x0a, 0x09, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x08, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x49, 0x64, 0x42,
+	0x08, 0x0a, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x42, 0x12, 0x5a, 0x10, 0x6f, 0x74, 0x6d,
+	0x61, 0x6e, 0x65, 0x2f, 0x70, 0x63, 0x62, 0x6f, 0x6f, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_record_message_proto_rawDescOnce sync.Once
+	file_record_message_proto_rawDescData = file_record_message_proto_rawDesc
+)
+
+func file_record_message_proto_rawDescGZIP() []byte {
+	file_record_message_proto_rawDescOnce.Do(func() {
+		file_record_message_proto_rawDescData = protoimpl.X.CompressGZIP(file_record_message_proto_rawDescData)
+	})
+	return file_record_message_proto_rawDescData
+}
+
+var file_record_message_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_record_message_proto_goTypes = []interface{}{
+	(*LaptopRecord)(nil), // 0: pb.LaptopRecord
+	(*Laptop)(nil),       // 1: pb.Laptop
+}
+var file_record_message_proto_depIdxs = []int32{
+	1, // 0: pb.LaptopRecord.put:type_name -> pb.Laptop
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_record_message_proto_init() }
+func file_record_message_proto_init() {
+	if File_record_message_proto != nil {
+		return
+	}
+	file_laptop_message_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_record_message_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LaptopRecord); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_record_message_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*LaptopRecord_Put)(nil),
+		(*LaptopRecord_DeleteId)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_record_message_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_record_message_proto_goTypes,
+		DependencyIndexes: file_record_message_proto_depIdxs,
+		MessageInfos:      file_record_message_proto_msgTypes,
+	}.Build()
+	File_record_message_proto = out.File
+	file_record_message_proto_rawDesc = nil
+	file_record_message_proto_goTypes = nil
+	file_record_message_proto_depIdxs = nil
+}
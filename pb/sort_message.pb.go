@@ -0,0 +1,225 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        v3.12.4
+// source: sort_message.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type SortOrder_Key int32
+
+const (
+	SortOrder_UNKNOWN      SortOrder_Key = 0
+	SortOrder_PRICE_USD    SortOrder_Key = 1
+	SortOrder_RELEASE_YEAR SortOrder_Key = 2
+	SortOrder_CPU_MAX_GHZ  SortOrder_Key = 3
+	SortOrder_RAM          SortOrder_Key = 4
+	SortOrder_RATING       SortOrder_Key = 5
+	SortOrder_RELEVANCE    SortOrder_Key = 6
+)
+
+// Enum value maps for SortOrder_Key.
+var (
+	SortOrder_Key_name = map[int32]string{
+		0: "UNKNOWN",
+		1: "PRICE_USD",
+		2: "RELEASE_YEAR",
+		3: "CPU_MAX_GHZ",
+		4: "RAM",
+		5: "RATING",
+		6: "RELEVANCE",
+	}
+	SortOrder_Key_value = map[string]int32{
+		"UNKNOWN":      0,
+		"PRICE_USD":    1,
+		"RELEASE_YEAR": 2,
+		"CPU_MAX_GHZ":  3,
+		"RAM":          4,
+		"RATING":       5,
+		"RELEVANCE":    6,
+	}
+)
+
+func (x SortOrder_Key) Enum() *SortOrder_Key {
+	p := new(SortOrder_Key)
+	*p = x
+	return p
+}
+
+func (x SortOrder_Key) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder_Key) Descriptor() protoreflect.EnumDescriptor {
+	return file_sort_message_proto_enumTypes[0].Descriptor()
+}
+
+func (SortOrder_Key) Type() protoreflect.EnumType {
+	return &file_sort_message_proto_enumTypes[0]
+}
+
+func (x SortOrder_Key) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder_Key.Descriptor instead.
+func (SortOrder_Key) EnumDescriptor() ([]byte, []int) {
+	return file_sort_message_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type SortOrder struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Key        SortOrder_Key `protobuf:"varint,1,opt,name=key,proto3,enum=pb.SortOrder_Key" json:"key,omitempty"`
+	Descending bool          `protobuf:"varint,2,opt,name=descending,proto3" json:"descending,omitempty"`
+}
+
+func (x *SortOrder) Reset() {
+	*x = SortOrder{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_sort_message_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SortOrder) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SortOrder) ProtoMessage() {}
+
+func (x *SortOrder) ProtoReflect() protoreflect.Message {
+	mi := &file_sort_message_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SortOrder.ProtoReflect.Descriptor instead.
+func (*SortOrder) Descriptor() ([]byte, []int) {
+	return file_sort_message_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SortOrder) GetKey() SortOrder_Key {
+	if x != nil {
+		return x.Key
+	}
+	return SortOrder_UNKNOWN
+}
+
+func (x *SortOrder) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+var File_sort_message_proto protoreflect.FileDescriptor
+
+var file_sort_message_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x73, 0x6f, 0x72, 0x74, 0x5f, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x02, 0x70, 0x62, 0x22, 0xba, 0x01, 0x0a, 0x09, 0x53, 0x6f, 0x72,
+	0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x23, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x70, 0x62, 0x2e, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64,
+	0x65, 0x72, 0x2e, 0x4b, 0x65, 0x79, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x64,
+	0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0a, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x64, 0x69, 0x6e, 0x67, 0x22, 0x68, 0x0a, 0x03, 0x4b,
+	0x65, 0x79, 0x12, 0x0b, 0x0a, 0x07, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12,
+	0x0d, 0x0a, 0x09, 0x50, 0x52, 0x49, 0x43, 0x45, 0x5f, 0x55, 0x53, 0x44, 0x10, 0x01, 0x12, 0x10,
+	0x0a, 0x0c, 0x52, 0x45, 0x4c, 0x45, 0x41, 0x53, 0x45, 0x5f, 0x59, 0x45, 0x41, 0x52, 0x10, 0x02,
+	0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x50, 0x55, 0x5f, 0x4d, 0x41, 0x58, 0x5f, 0x47, 0x48, 0x5a, 0x10,
+	0x03, 0x12, 0x07, 0x0a, 0x03, 0x52, 0x41, 0x4d, 0x10, 0x04, 0x12, 0x0a, 0x0a, 0x06, 0x52, 0x41,
+	0x54, 0x49, 0x4e, 0x47, 0x10, 0x05, 0x12, 0x0d, 0x0a, 0x09, 0x52, 0x45, 0x4c, 0x45, 0x56, 0x41,
+	0x4e, 0x43, 0x45, 0x10, 0x06, 0x42, 0x12, 0x5a, 0x10, 0x6f, 0x74, 0x6d, 0x61, 0x6e, 0x65, 0x2f,
+	0x70, 0x63, 0x62, 0x6f, 0x6f, 0x6b, 0x2f, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_sort_message_proto_rawDescOnce sync.Once
+	file_sort_message_proto_rawDescData = file_sort_message_proto_rawDesc
+)
+
+func file_sort_message_proto_rawDescGZIP() []byte {
+	file_sort_message_proto_rawDescOnce.Do(func() {
+		file_sort_message_proto_rawDescData = protoimpl.X.CompressGZIP(file_sort_message_proto_rawDescData)
+	})
+	return file_sort_message_proto_rawDescData
+}
+
+var file_sort_message_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_sort_message_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_sort_message_proto_goTypes = []interface{}{
+	(SortOrder_Key)(0), // 0: pb.SortOrder.Key
+	(*SortOrder)(nil),  // 1: pb.SortOrder
+}
+var file_sort_message_proto_depIdxs = []int32{
+	0, // 0: pb.SortOrder.key:type_name -> pb.SortOrder.Key
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_sort_message_proto_init() }
+func file_sort_message_proto_init() {
+	if File_sort_message_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_sort_message_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SortOrder); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_sort_message_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_sort_message_proto_goTypes,
+		DependencyIndexes: file_sort_message_proto_depIdxs,
+		EnumInfos:         file_sort_message_proto_enumTypes,
+		MessageInfos:      file_sort_message_proto_msgTypes,
+	}.Build()
+	File_sort_message_proto = out.File
+	file_sort_message_proto_rawDesc = nil
+	file_sort_message_proto_goTypes = nil
+	file_sort_message_proto_depIdxs = nil
+}
@@ -0,0 +1,237 @@
+package query
+
+import (
+	"sort"
+	"strings"
+
+	"otmane/pcbook/pb"
+)
+
+// kind is the type of the values of a field and of the literals compared to it
+type kind int
+
+const (
+	kindString kind = iota
+	kindNumber
+	kindMemory
+	kindWeight
+	kindBool
+)
+
+func (k kind) String() string {
+	switch k {
+	case kindString:
+		return "string"
+	case kindNumber:
+		return "number"
+	case kindMemory:
+		return "memory size"
+	case kindWeight:
+		return "weight"
+	default:
+		return "boolean"
+	}
+}
+
+// field extracts the values of a laptop a query can compare. Fields with several
+// values, like the GPUs of a laptop, match if any of their values matches.
+type field struct {
+	kind    kind
+	strings func(laptop *pb.Laptop) []string
+	numbers func(laptop *pb.Laptop) []float64
+}
+
+func stringField(get func(laptop *pb.Laptop) string) field {
+	return field{kind: kindString, strings: func(laptop *pb.Laptop) []string {
+		return []string{get(laptop)}
+	}}
+}
+
+func numberField(kind kind, get func(laptop *pb.Laptop) float64) field {
+	return field{kind: kind, numbers: func(laptop *pb.Laptop) []float64 {
+		return []float64{get(laptop)}
+	}}
+}
+
+func boolField(get func(laptop *pb.Laptop) bool) field {
+	return numberField(kindBool, func(laptop *pb.Laptop) float64 {
+		return boolNumber(get(laptop))
+	})
+}
+
+var fields = map[string]field{
+	"brand": stringField((*pb.Laptop).GetBrand),
+	"name":  stringField((*pb.Laptop).GetName),
+	"price": numberField(kindNumber, (*pb.Laptop).GetPriceUsd),
+	"year": numberField(kindNumber, func(laptop *pb.Laptop) float64 {
+		return float64(laptop.GetReleaseYear())
+	}),
+	"ram": numberField(kindMemory, func(laptop *pb.Laptop) float64 {
+		return memoryBits(laptop.GetRam())
+	}),
+	"weight": {kind: kindWeight, numbers: func(laptop *pb.Laptop) []float64 {
+		switch weight := laptop.GetWeight().(type) {
+		case *pb.Laptop_WeightKg:
+			return []float64{weight.WeightKg}
+		case *pb.Laptop_WeightLb:
+			return []float64{weight.WeightLb * kgPerLb}
+		default:
+			return nil
+		}
+	}},
+
+	"cpu.brand": stringField(func(laptop *pb.Laptop) string {
+		return laptop.GetCpu().GetBrand()
+	}),
+	"cpu.name": stringField(func(laptop *pb.Laptop) string {
+		return laptop.GetCpu().GetName()
+	}),
+	"cpu.cores": numberField(kindNumber, func(laptop *pb.Laptop) float64 {
+		return float64(laptop.GetCpu().GetNumberCores())
+	}),
+	"cpu.threads": numberField(kindNumber, func(laptop *pb.Laptop) float64 {
+		return float64(laptop.GetCpu().GetNumberThreads())
+	}),
+	"cpu.min_ghz": numberField(kindNumber, func(laptop *pb.Laptop) float64 {
+		return laptop.GetCpu().GetMinGhz()
+	}),
+	"cpu.max_ghz": numberField(kindNumber, func(laptop *pb.Laptop) float64 {
+		return laptop.GetCpu().GetMaxGhz()
+	}),
+
+	"gpu.brand": {kind: kindString, strings: func(laptop *pb.Laptop) []string {
+		var values []string
+		for _, gpu := range laptop.GetGpus() {
+			values = append(values, gpu.GetBrand())
+		}
+		return values
+	}},
+	"gpu.name": {kind: kindString, strings: func(laptop *pb.Laptop) []string {
+		var values []string
+		for _, gpu := range laptop.GetGpus() {
+			values = append(values, gpu.GetName())
+		}
+		return values
+	}},
+	"gpu.memory": {kind: kindMemory, numbers: func(laptop *pb.Laptop) []float64 {
+		var values []float64
+		for _, gpu := range laptop.GetGpus() {
+			values = append(values, memoryBits(gpu.GetMemory()))
+		}
+		return values
+	}},
+
+	"storage": numberField(kindMemory, func(laptop *pb.Laptop) float64 {
+		var total float64
+		for _, storage := range laptop.GetStorages() {
+			total += memoryBits(storage.GetMemory())
+		}
+		return total
+	}),
+	"storage.driver": {kind: kindString, strings: func(laptop *pb.Laptop) []string {
+		var values []string
+		for _, storage := range laptop.GetStorages() {
+			values = append(values, storage.GetDriver().String())
+		}
+		return values
+	}},
+
+	"screen.size": numberField(kindNumber, func(laptop *pb.Laptop) float64 {
+		return float64(laptop.GetScreen().GetSizeInch())
+	}),
+	"screen.width": numberField(kindNumber, func(laptop *pb.Laptop) float64 {
+		return float64(laptop.GetScreen().GetResolution().GetWidth())
+	}),
+	"screen.height": numberField(kindNumber, func(laptop *pb.Laptop) float64 {
+		return float64(laptop.GetScreen().GetResolution().GetHeight())
+	}),
+	"screen.panel": stringField(func(laptop *pb.Laptop) string {
+		return laptop.GetScreen().GetPanel().String()
+	}),
+	"screen.multitouch": boolField(func(laptop *pb.Laptop) bool {
+		return laptop.GetScreen().GetMultitouch()
+	}),
+
+	"keyboard.layout": stringField(func(laptop *pb.Laptop) string {
+		return laptop.GetKeyboard().GetLayout().String()
+	}),
+	"keyboard.backlit": boolField(func(laptop *pb.Laptop) bool {
+		return laptop.GetKeyboard().GetBacklit()
+	}),
+}
+
+// fieldAliases are the alternative names of the fields, matching the laptop message
+var fieldAliases = map[string]string{
+	"price_usd":    "price",
+	"release_year": "year",
+	"memory":       "ram",
+}
+
+func lookupField(name string) (field, bool) {
+	name = strings.ToLower(name)
+	if alias, ok := fieldAliases[name]; ok {
+		name = alias
+	}
+
+	f, ok := fields[name]
+	return f, ok
+}
+
+// fieldNames returns the sorted names of the fields, to help fixing an unknown one
+func fieldNames() string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return strings.Join(names, ", ")
+}
+
+const kgPerLb = 0.45359237
+
+// memoryUnits are the number of bits of each memory unit of the literals
+var memoryUnits = map[string]float64{
+	"bit": 1,
+	"b":   1 << 3,
+	"kb":  1 << 13,
+	"mb":  1 << 23,
+	"gb":  1 << 33,
+	"tb":  1 << 43,
+}
+
+// weightUnits are the number of kilograms of each weight unit of the literals
+var weightUnits = map[string]float64{
+	"g":   0.001,
+	"kg":  1,
+	"lb":  kgPerLb,
+	"lbs": kgPerLb,
+}
+
+func memoryBits(memory *pb.Memory) float64 {
+	value := float64(memory.GetValue())
+
+	switch memory.GetUnit() {
+	case pb.Memory_BIT:
+		return value
+	case pb.Memory_BYTE:
+		return value * memoryUnits["b"]
+	case pb.Memory_KILOBYTE:
+		return value * memoryUnits["kb"]
+	case pb.Memory_MEGABYTE:
+		return value * memoryUnits["mb"]
+	case pb.Memory_GIGABYTE:
+		return value * memoryUnits["gb"]
+	case pb.Memory_TERABYTE:
+		return value * memoryUnits["tb"]
+	default:
+		return 0
+	}
+}
+
+func boolNumber(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,154 @@
+package query
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+)
+
+func (kind tokenKind) String() string {
+	switch kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenIdent:
+		return "identifier"
+	case tokenString:
+		return "string"
+	case tokenNumber:
+		return "number"
+	case tokenOperator:
+		return "operator"
+	case tokenLeftParen:
+		return `"("`
+	case tokenRightParen:
+		return `")"`
+	default:
+		return `","`
+	}
+}
+
+// token is a lexical token of a query, pos is its 1-based position in the query
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// is reports whether the token is the given keyword, keywords are case-insensitive
+func (tok token) is(keyword string) bool {
+	return tok.kind == tokenIdent && strings.EqualFold(tok.text, keyword)
+}
+
+func (tok token) String() string {
+	if tok.kind == tokenEOF {
+		return tok.kind.String()
+	}
+
+	return `"` + tok.text + `"`
+}
+
+// tokenize splits a query into tokens, ending with a tokenEOF token
+func tokenize(query string) ([]token, error) {
+	var tokens []token
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, text: "(", pos: pos})
+			i++
+
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, text: ")", pos: pos})
+			i++
+
+		case r == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: pos})
+			i++
+
+		case r == '"' || r == '\'':
+			text, next, err := lexString(runes, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, text: text, pos: pos})
+			i = next
+
+		case unicode.IsDigit(r):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[start:i]), pos: pos})
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), pos: pos})
+
+		case strings.ContainsRune("=!<>", r):
+			op := string(r)
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				op += "="
+			}
+			i += len(op)
+
+			switch op {
+			case "!":
+				return nil, errorf(pos, `unexpected "!", did you mean "!="?`)
+			case "==":
+				op = "="
+			}
+			tokens = append(tokens, token{kind: tokenOperator, text: op, pos: pos})
+
+		default:
+			return nil, errorf(pos, "unexpected character %q", r)
+		}
+	}
+
+	tokens = append(tokens, token{kind: tokenEOF, pos: len(runes) + 1})
+	return tokens, nil
+}
+
+// lexString reads the quoted string starting at runes[start] and returns its content
+// and the position after the closing quote
+func lexString(runes []rune, start int) (string, int, error) {
+	quote := runes[start]
+	var text strings.Builder
+
+	for i := start + 1; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			if i+1 < len(runes) {
+				i++
+				text.WriteRune(runes[i])
+			}
+		case quote:
+			return text.String(), i + 1, nil
+		default:
+			text.WriteRune(runes[i])
+		}
+	}
+
+	return "", 0, errorf(start+1, "unterminated string")
+}
@@ -0,0 +1,391 @@
+// Package query compiles textual laptop queries into predicates, for example
+//
+//	brand in ("Apple", "Dell") and ram >= 16GB and price < 2000 and gpu.brand = "NVIDIA"
+//
+// Comparisons of a field with a literal are combined with and, or, not and
+// parentheses. Strings are compared case-insensitively, memory literals take a
+// unit among bit, B, KB, MB, GB and TB, and weight literals among g, kg and lb,
+// a weight without unit being in kilograms.
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"otmane/pcbook/pb"
+)
+
+const (
+	// MAX_QUERY_LENGTH is the maximum length of a query in bytes
+	MAX_QUERY_LENGTH = 4096
+	// MAX_NESTING_DEPTH is the maximum number of nested parentheses and "not" of a query
+	MAX_NESTING_DEPTH = 64
+)
+
+// Error is an invalid query error, Pos is the 1-based position of the problem in the query
+type Error struct {
+	Pos int
+	Msg string
+}
+
+func (err *Error) Error() string {
+	return fmt.Sprintf("position %d: %s", err.Pos, err.Msg)
+}
+
+func errorf(pos int, format string, args ...interface{}) error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Predicate reports whether a laptop is selected by a query
+type Predicate func(laptop *pb.Laptop) bool
+
+// Compile parses a query and returns the predicate selecting the laptops it matches.
+// The returned error is an *Error when the query is invalid.
+func Compile(query string) (Predicate, error) {
+	if len(query) > MAX_QUERY_LENGTH {
+		return nil, errorf(MAX_QUERY_LENGTH+1, "query is longer than %d bytes", MAX_QUERY_LENGTH)
+	}
+
+	tokens, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	if p.peek().kind == tokenEOF {
+		return nil, errorf(1, "empty query")
+	}
+
+	predicate, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, errorf(tok.pos, "unexpected %s, expected \"and\" or \"or\"", tok)
+	}
+
+	return predicate, nil
+}
+
+var keywords = []string{"and", "or", "not", "in", "true", "false"}
+
+func isKeyword(tok token) bool {
+	for _, keyword := range keywords {
+		if tok.is(keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parser is a recursive descent parser of the query grammar:
+//
+//	or         = and { "or" and }
+//	and        = unary { "and" unary }
+//	unary      = "not" unary | "(" or ")" | comparison
+//	comparison = field operator literal | field [ "not" ] "in" "(" literal { "," literal } ")"
+type parser struct {
+	tokens []token
+	pos    int
+	// depth is the number of parentheses and "not" the current token is nested in
+	depth int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+
+	return tok
+}
+
+func (p *parser) expect(kind tokenKind) error {
+	if tok := p.next(); tok.kind != kind {
+		return errorf(tok.pos, "expected %s, got %s", kind, tok)
+	}
+
+	return nil
+}
+
+func (p *parser) parseOr() (Predicate, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().is("or") {
+		p.next()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = or(left, right)
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Predicate, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().is("and") {
+		p.next()
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = and(left, right)
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Predicate, error) {
+	if tok := p.peek(); tok.is("not") || tok.kind == tokenLeftParen {
+		if p.depth == MAX_NESTING_DEPTH {
+			return nil, errorf(tok.pos, "query is nested deeper than %d levels", MAX_NESTING_DEPTH)
+		}
+
+		p.depth++
+		defer func() { p.depth-- }()
+	}
+
+	if p.peek().is("not") {
+		p.next()
+
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return not(inner), nil
+	}
+
+	if p.peek().kind == tokenLeftParen {
+		p.next()
+
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		return inner, p.expect(tokenRightParen)
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (Predicate, error) {
+	name := p.next()
+	if name.kind != tokenIdent || isKeyword(name) {
+		return nil, errorf(name.pos, "expected a field name, got %s", name)
+	}
+
+	f, ok := lookupField(name.text)
+	if !ok {
+		return nil, errorf(name.pos, "unknown field %q, expected one of: %s", name.text, fieldNames())
+	}
+
+	op := p.next()
+	if op.is("not") && p.peek().is("in") {
+		p.next()
+
+		predicate, err := p.parseIn(f)
+		if err != nil {
+			return nil, err
+		}
+
+		return not(predicate), nil
+	}
+	if op.is("in") {
+		return p.parseIn(f)
+	}
+
+	if op.kind != tokenOperator {
+		return nil, errorf(op.pos, "expected a comparison operator after %s, got %s", name, op)
+	}
+	if (f.kind == kindString || f.kind == kindBool) && op.text != "=" && op.text != "!=" {
+		return nil, errorf(op.pos, "operator %s cannot compare %s values", op, f.kind)
+	}
+
+	value, err := p.parseLiteral(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return compare(f, op.text, value), nil
+}
+
+func (p *parser) parseIn(f field) (Predicate, error) {
+	if err := p.expect(tokenLeftParen); err != nil {
+		return nil, err
+	}
+
+	var predicates []Predicate
+	for {
+		value, err := p.parseLiteral(f)
+		if err != nil {
+			return nil, err
+		}
+		predicates = append(predicates, compare(f, "=", value))
+
+		if p.peek().kind != tokenComma {
+			break
+		}
+		p.next()
+	}
+
+	if err := p.expect(tokenRightParen); err != nil {
+		return nil, err
+	}
+
+	return func(laptop *pb.Laptop) bool {
+		for _, predicate := range predicates {
+			if predicate(laptop) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// literal is a value compared to a field, numbers are converted to bits for memory sizes
+// and to kilograms for weights
+type literal struct {
+	str string
+	num float64
+}
+
+func (p *parser) parseLiteral(f field) (literal, error) {
+	tok := p.next()
+
+	switch f.kind {
+	case kindString:
+		if tok.kind == tokenString || (tok.kind == tokenIdent && !isKeyword(tok)) {
+			return literal{str: tok.text}, nil
+		}
+
+	case kindBool:
+		if tok.is("true") || tok.is("false") {
+			return literal{num: boolNumber(tok.is("true"))}, nil
+		}
+
+	case kindNumber:
+		if tok.kind == tokenNumber {
+			return p.parseNumber(tok, nil)
+		}
+
+	case kindMemory:
+		if tok.kind == tokenNumber {
+			return p.parseNumber(tok, memoryUnits)
+		}
+
+	case kindWeight:
+		if tok.kind == tokenNumber {
+			return p.parseNumber(tok, weightUnits)
+		}
+	}
+
+	return literal{}, errorf(tok.pos, "expected a %s, got %s", f.kind, tok)
+}
+
+// parseNumber parses a number token followed by one of the units, or by no unit for a plain
+// number or a weight in kilograms
+func (p *parser) parseNumber(tok token, units map[string]float64) (literal, error) {
+	value, err := strconv.ParseFloat(tok.text, 64)
+	if err != nil {
+		return literal{}, errorf(tok.pos, "invalid number %s", tok)
+	}
+
+	if units == nil {
+		return literal{num: value}, nil
+	}
+
+	unit := p.peek()
+	factor, ok := units[strings.ToLower(unit.text)]
+	if unit.kind == tokenIdent && ok {
+		p.next()
+		return literal{num: value * factor}, nil
+	}
+
+	if _, isWeight := units["kg"]; isWeight {
+		return literal{num: value}, nil
+	}
+
+	return literal{}, errorf(unit.pos, "expected a memory unit (bit, B, KB, MB, GB, TB) after %s, got %s", tok, unit)
+}
+
+// compare returns the predicate comparing the values of the field to the literal,
+// a field matches if any of its values matches, and != holds when none is equal
+func compare(f field, op string, value literal) Predicate {
+	if op == "!=" {
+		return not(compare(f, "=", value))
+	}
+
+	if f.kind == kindString {
+		return func(laptop *pb.Laptop) bool {
+			for _, v := range f.strings(laptop) {
+				if strings.EqualFold(v, value.str) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	var match func(v float64) bool
+	switch op {
+	case "<":
+		match = func(v float64) bool { return v < value.num }
+	case "<=":
+		match = func(v float64) bool { return v <= value.num }
+	case ">":
+		match = func(v float64) bool { return v > value.num }
+	case ">=":
+		match = func(v float64) bool { return v >= value.num }
+	default:
+		match = func(v float64) bool { return v == value.num }
+	}
+
+	return func(laptop *pb.Laptop) bool {
+		for _, v := range f.numbers(laptop) {
+			if match(v) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func and(left, right Predicate) Predicate {
+	return func(laptop *pb.Laptop) bool {
+		return left(laptop) && right(laptop)
+	}
+}
+
+func or(left, right Predicate) Predicate {
+	return func(laptop *pb.Laptop) bool {
+		return left(laptop) || right(laptop)
+	}
+}
+
+func not(inner Predicate) Predicate {
+	return func(laptop *pb.Laptop) bool {
+		return !inner(laptop)
+	}
+}
@@ -0,0 +1,97 @@
+package query_test
+
+import (
+	"strings"
+	"testing"
+
+	"otmane/pcbook/pb"
+	"otmane/pcbook/query"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompile(t *testing.T) {
+	t.Parallel()
+
+	laptop := &pb.Laptop{
+		Brand: "Dell",
+		Name:  "XPS",
+		Cpu:   &pb.CPU{Brand: "Intel", NumberCores: 6, MaxGhz: 4.5},
+		Ram:   &pb.Memory{Value: 16, Unit: pb.Memory_GIGABYTE},
+		Gpus: []*pb.GPU{
+			{Brand: "NVIDIA", Name: "RTX 2060", Memory: &pb.Memory{Value: 6, Unit: pb.Memory_GIGABYTE}},
+		},
+		Storages: []*pb.Storage{
+			{Driver: pb.Storage_SSD, Memory: &pb.Memory{Value: 512, Unit: pb.Memory_GIGABYTE}},
+		},
+		Screen:      &pb.Screen{SizeInch: 15.6, Panel: pb.Screen_OLED},
+		Keyboard:    &pb.Keyboard{Layout: pb.Keyboard_QWERTY, Backlit: true},
+		Weight:      &pb.Laptop_WeightLb{WeightLb: 4.4},
+		PriceUsd:    1899,
+		ReleaseYear: 2019,
+	}
+
+	testCases := []struct {
+		query   string
+		matches bool
+	}{
+		{`brand in ("Apple","Dell") and ram >= 16GB and price < 2000 and gpu.brand = "NVIDIA"`, true},
+		{`brand = 'dell'`, true},
+		{`brand != "Dell"`, false},
+		{`brand not in (Apple, Lenovo)`, true},
+		{`ram > 16 GB`, false},
+		{`ram = 16384MB`, true},
+		{`gpu.memory >= 8gb or cpu.cores >= 6`, true},
+		{`not (gpu.memory >= 8gb or cpu.cores >= 6)`, false},
+		{`storage >= 0.5TB and storage.driver = SSD and storage.driver != HDD`, true},
+		{`weight <= 2kg and weight > 4lb`, true},
+		{`weight < 1.9`, false},
+		{`screen.panel = oled and keyboard.backlit = true`, true},
+		{`year >= 2018 and release_year < 2020 and cpu.max_ghz > 4`, true},
+		{`price_usd == 1899 or brand = "Apple" and year < 2000`, true},
+		{`(price_usd == 1899 or brand = "Apple") and year < 2000`, false},
+		{strings.Repeat("(", 64) + `brand = "Dell"` + strings.Repeat(")", 64), true},
+	}
+
+	for _, tc := range testCases {
+		predicate, err := query.Compile(tc.query)
+		require.NoError(t, err, tc.query)
+		require.Equal(t, tc.matches, predicate(laptop), tc.query)
+	}
+}
+
+func TestCompileError(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		query string
+		pos   int
+		msg   string
+	}{
+		{``, 1, "empty query"},
+		{`brand = "Dell`, 9, "unterminated string"},
+		{`colour = "red"`, 1, `unknown field "colour"`},
+		{`ram >= 16`, 10, "expected a memory unit"},
+		{`price < "cheap"`, 9, "expected a number"},
+		{`brand < "Dell"`, 7, "cannot compare string values"},
+		{`brand = "Dell" and`, 19, "expected a field name, got end of query"},
+		{`(brand = "Dell"`, 16, `expected ")"`},
+		{`brand in "Dell"`, 10, `expected "("`},
+		{`brand = "Dell" price < 2000`, 16, `unexpected "price"`},
+		{`price ! 2000`, 7, `did you mean "!="`},
+		{`price < 20.0.0`, 9, "invalid number"},
+		{strings.Repeat("(", 65) + `brand = "Dell"` + strings.Repeat(")", 65), 65, "nested deeper than 64 levels"},
+		{strings.Repeat("not ", 65) + `brand = "Dell"`, 257, "nested deeper than 64 levels"},
+		{`brand = "` + strings.Repeat("a", query.MAX_QUERY_LENGTH) + `"`, query.MAX_QUERY_LENGTH + 1, "longer than 4096 bytes"},
+	}
+
+	for _, tc := range testCases {
+		_, err := query.Compile(tc.query)
+		require.Error(t, err, tc.query)
+
+		queryErr, ok := err.(*query.Error)
+		require.True(t, ok, tc.query)
+		require.Equal(t, tc.pos, queryErr.Pos, tc.query)
+		require.Contains(t, queryErr.Msg, tc.msg, tc.query)
+	}
+}
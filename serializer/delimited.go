@@ -0,0 +1,109 @@
+package serializer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+)
+
+// DelimitedFileWriter writes protocol buffer messages prefixed by their size to a temporary file,
+// which replaces the file with its name only once it is committed
+type DelimitedFileWriter struct {
+	filename string
+	file     *os.File
+	writer   *bufio.Writer
+}
+
+// CreateDelimitedFile returns a writer of the delimited messages of the file
+func CreateDelimitedFile(filename string) (*DelimitedFileWriter, error) {
+	file, err := os.CreateTemp(filepath.Dir(filename), filepath.Base(filename)+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("cannot create temporary file: %w", err)
+	}
+
+	return &DelimitedFileWriter{
+		filename: filename,
+		file:     file,
+		writer:   bufio.NewWriter(file),
+	}, nil
+}
+
+// Write writes the message prefixed by its size
+func (w *DelimitedFileWriter) Write(message proto.Message) error {
+	_, err := protodelim.MarshalTo(w.writer, message)
+	if err != nil {
+		return fmt.Errorf("cannot write proto message: %w", err)
+	}
+
+	return nil
+}
+
+// Commit syncs the written messages to disk and renames the temporary file to the name of the file
+func (w *DelimitedFileWriter) Commit() error {
+	err := w.writer.Flush()
+	if err == nil {
+		err = w.file.Sync()
+	}
+	if err != nil {
+		w.Close()
+		return fmt.Errorf("cannot write temporary file: %w", err)
+	}
+
+	err = w.file.Close()
+	if err != nil {
+		os.Remove(w.file.Name())
+		return fmt.Errorf("cannot close temporary file: %w", err)
+	}
+
+	err = os.Rename(w.file.Name(), w.filename)
+	if err != nil {
+		os.Remove(w.file.Name())
+		return fmt.Errorf("cannot rename temporary file: %w", err)
+	}
+
+	return nil
+}
+
+// Close removes the temporary file if it was not committed, leaving the file unchanged
+func (w *DelimitedFileWriter) Close() error {
+	err := w.file.Close()
+	if errors.Is(err, os.ErrClosed) {
+		return nil
+	}
+
+	os.Remove(w.file.Name())
+	return err
+}
+
+// ReadProtobufFromDelimitedFile reads the protocol buffer messages prefixed by their size from a file,
+// unmarshaling each of them into a message returned by newMessage and passing it to read
+func ReadProtobufFromDelimitedFile(filename string, newMessage func() proto.Message, read func(message proto.Message) error) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("cannot open delimited file: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		message := newMessage()
+		err := protodelim.UnmarshalFrom(reader, message)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("cannot unmarshal delimited proto message: %w", err)
+		}
+
+		err = read(message)
+		if err != nil {
+			return err
+		}
+	}
+}
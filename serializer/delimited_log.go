@@ -0,0 +1,176 @@
+package serializer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+)
+
+// DelimitedLog is a log of changes written as protocol buffer messages prefixed by their size, each of
+// them synced before being applied. Compacting it writes the state the changes lead to in a snapshot
+// file, which is read before the log, and empties the log.
+type DelimitedLog struct {
+	snapshotFilename string
+	file             *os.File
+	size             int64 // size of the log, to drop a partially written message
+	records          int   // number of messages in the log
+}
+
+// OpenDelimitedLog reads the messages of the snapshot file and then of the log file, unmarshaling each of them
+// into a message returned by newMessage and passing it to read, and opens the log to append the next ones.
+// A missing file has no messages.
+func OpenDelimitedLog(
+	snapshotFilename string,
+	filename string,
+	newMessage func() proto.Message,
+	read func(message proto.Message) error,
+) (*DelimitedLog, error) {
+	_, _, err := readDelimitedLog(snapshotFilename, newMessage, read)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &DelimitedLog{snapshotFilename: snapshotFilename}
+	l.records, l.size, err = readDelimitedLog(filename, newMessage, read)
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		// the process stopped while appending the last message, which was not applied
+		log.Printf("truncate the incomplete message at the end of %s: %v", filename, err)
+		err = os.Truncate(filename, l.size)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	l.file, err = os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open the log: %w", err)
+	}
+
+	return l, nil
+}
+
+// readDelimitedLog passes the messages of the file to read and returns their number and size.
+// The error wraps io.ErrUnexpectedEOF when the file ends with an incomplete message.
+func readDelimitedLog(filename string, newMessage func() proto.Message, read func(message proto.Message) error) (int, int64, error) {
+	data, err := os.ReadFile(filename)
+	if os.IsNotExist(err) {
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, fmt.Errorf("cannot read %s: %w", filename, err)
+	}
+
+	reader := bytes.NewReader(data)
+	records := 0
+	for {
+		size := reader.Size() - int64(reader.Len())
+
+		message := newMessage()
+		err := protodelim.UnmarshalFrom(reader, message)
+		if err == io.EOF {
+			return records, size, nil
+		}
+		if err != nil {
+			return records, size, fmt.Errorf("cannot read message %d of %s: %w", records+1, filename, err)
+		}
+
+		err = read(message)
+		if err != nil {
+			return records, size, err
+		}
+		records++
+	}
+}
+
+// Append appends the message to the log and syncs it, the log is left unchanged if it fails
+func (l *DelimitedLog) Append(message proto.Message) error {
+	var buffer bytes.Buffer
+	_, err := protodelim.MarshalTo(&buffer, message)
+	if err != nil {
+		return fmt.Errorf("cannot encode proto message: %w", err)
+	}
+
+	_, err = l.file.Write(buffer.Bytes())
+	if err == nil {
+		err = l.file.Sync()
+	}
+	if err != nil {
+		if truncateErr := l.file.Truncate(l.size); truncateErr != nil {
+			log.Printf("cannot truncate %s: %v", l.file.Name(), truncateErr)
+		}
+		return fmt.Errorf("cannot write to the log: %w", err)
+	}
+
+	l.size += int64(buffer.Len())
+	l.records++
+	return nil
+}
+
+// Records returns the number of messages appended to the log since it was last compacted
+func (l *DelimitedLog) Records() int {
+	return l.records
+}
+
+// Compact replaces the snapshot file with the messages written by write, which must be the state
+// all the messages of the snapshot and of the log lead to, and empties the log
+func (l *DelimitedLog) Compact(write func(writer *DelimitedFileWriter) error) error {
+	writer, err := CreateDelimitedFile(l.snapshotFilename)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	err = write(writer)
+	if err == nil {
+		err = writer.Commit()
+	}
+	if err != nil {
+		return fmt.Errorf("cannot write the snapshot: %w", err)
+	}
+
+	// a crash before the log is emptied replays it over the new snapshot, which ends in the same state
+	err = syncDir(filepath.Dir(l.snapshotFilename))
+	if err != nil {
+		return err
+	}
+
+	err = l.file.Truncate(0)
+	if err == nil {
+		err = l.file.Sync()
+	}
+	if err != nil {
+		return fmt.Errorf("cannot empty the log: %w", err)
+	}
+
+	l.size = 0
+	l.records = 0
+	return nil
+}
+
+// Close closes the log file
+func (l *DelimitedLog) Close() error {
+	return l.file.Close()
+}
+
+// syncDir syncs the directory, so that the files renamed into it survive a crash
+func syncDir(dir string) error {
+	file, err := os.Open(dir)
+	if err != nil {
+		return fmt.Errorf("cannot open the directory: %w", err)
+	}
+	defer file.Close()
+
+	err = file.Sync()
+	if err != nil {
+		return fmt.Errorf("cannot sync the directory: %w", err)
+	}
+
+	return nil
+}
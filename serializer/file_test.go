@@ -1,6 +1,8 @@
 package serializer_test
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"otmane/pcbook/pb"
@@ -31,3 +33,106 @@ func TestFileSerializer(t *testing.T) {
 
 	require.True(t, proto.Equal(laptop1, laptop2))
 }
+
+func TestDelimitedFile(t *testing.T) {
+	t.Parallel()
+
+	filename := filepath.Join(t.TempDir(), "laptops.bin")
+	laptops := []*pb.Laptop{sample.NewLaptop(), sample.NewLaptop(), sample.NewLaptop()}
+
+	writer, err := serializer.CreateDelimitedFile(filename)
+	require.NoError(t, err)
+	for _, laptop := range laptops {
+		require.NoError(t, writer.Write(laptop))
+	}
+
+	// the file is written only once committed
+	require.NoFileExists(t, filename)
+	require.NoError(t, writer.Commit())
+	require.NoError(t, writer.Close())
+
+	var read []*pb.Laptop
+	err = serializer.ReadProtobufFromDelimitedFile(filename, func() proto.Message { return &pb.Laptop{} }, func(message proto.Message) error {
+		read = append(read, message.(*pb.Laptop))
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, read, len(laptops))
+	for i := range laptops {
+		require.True(t, proto.Equal(laptops[i], read[i]))
+	}
+
+	// a writer closed without committing leaves the file unchanged
+	writer, err = serializer.CreateDelimitedFile(filename)
+	require.NoError(t, err)
+	require.NoError(t, writer.Write(sample.NewLaptop()))
+	require.NoError(t, writer.Close())
+
+	entries, err := os.ReadDir(filepath.Dir(filename))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	count := 0
+	err = serializer.ReadProtobufFromDelimitedFile(filename, func() proto.Message { return &pb.Laptop{} }, func(message proto.Message) error {
+		count++
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, len(laptops), count)
+}
+
+func TestDelimitedLog(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	snapshotFilename := filepath.Join(dir, "laptops.snapshot")
+	filename := filepath.Join(dir, "laptops.log")
+
+	open := func() (*serializer.DelimitedLog, []string) {
+		var ids []string
+		l, err := serializer.OpenDelimitedLog(snapshotFilename, filename, func() proto.Message { return &pb.Laptop{} }, func(message proto.Message) error {
+			ids = append(ids, message.(*pb.Laptop).GetId())
+			return nil
+		})
+		require.NoError(t, err)
+		return l, ids
+	}
+
+	l, ids := open()
+	require.Empty(t, ids)
+
+	laptops := []*pb.Laptop{sample.NewLaptop(), sample.NewLaptop(), sample.NewLaptop()}
+	for _, laptop := range laptops[:2] {
+		require.NoError(t, l.Append(laptop))
+	}
+	require.Equal(t, 2, l.Records())
+
+	// the snapshot is read before the log, which is emptied
+	err := l.Compact(func(writer *serializer.DelimitedFileWriter) error {
+		return writer.Write(laptops[0])
+	})
+	require.NoError(t, err)
+	require.Zero(t, l.Records())
+
+	require.NoError(t, l.Append(laptops[2]))
+	require.NoError(t, l.Close())
+
+	// a crash while appending a message leaves the beginning of its length and content
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(t, err)
+	_, err = file.Write([]byte{0x80, 0x01, 0x0a})
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	l, ids = open()
+	require.Equal(t, []string{laptops[0].Id, laptops[2].Id}, ids)
+	require.Equal(t, 1, l.Records())
+
+	// the incomplete message was dropped, so the next ones are read again
+	require.NoError(t, l.Append(laptops[1]))
+	require.NoError(t, l.Close())
+
+	l, ids = open()
+	defer l.Close()
+	require.Equal(t, []string{laptops[0].Id, laptops[2].Id, laptops[1].Id}, ids)
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"log"
+
+	"otmane/pcbook/pb"
+)
+
+// AdminServer is the server for the administration of a laptop server
+type AdminServer struct {
+	pb.UnimplementedAdminServiceServer
+	laptopServer *LaptopServer
+	userStore    UserStore
+}
+
+// NewAdminServer returns a new admin server of the laptop server and the user store
+func NewAdminServer(laptopServer *LaptopServer, userStore UserStore) *AdminServer {
+	return &AdminServer{laptopServer: laptopServer, userStore: userStore}
+}
+
+// Snapshot is a server-streaming RPC that returns the records of a consistent snapshot of the laptops,
+// ratings, users and images of the server. The writes are blocked only while the stores are copied.
+func (server *AdminServer) Snapshot(req *pb.SnapshotRequest, stream pb.AdminService_SnapshotServer) error {
+	log.Print("receive a snapshot request")
+
+	snapshot, err := server.laptopServer.snapshot(stream.Context(), server.userStore)
+	if err != nil {
+		return logError(toRPCError(err, resource{}, "cannot take snapshot"))
+	}
+
+	err = snapshot.ForEachRecord(stream.Send)
+	if err != nil {
+		return logError(streamError(err, "cannot send snapshot"))
+	}
+
+	log.Printf("sent snapshot of %d laptops", len(snapshot.Laptops))
+	return nil
+}
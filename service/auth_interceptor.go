@@ -7,7 +7,6 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
 )
 
 // AuthInterceptor is a server interceptor for authentication and authorization.
@@ -64,18 +63,18 @@ func (interceptor *AuthInterceptor) authorize(ctx context.Context, method string
 
     md, ok := metadata.FromIncomingContext(ctx)
     if !ok {
-        return status.Error(codes.Unauthenticated, "metadata is not provided")
+        return newRPCError(codes.Unauthenticated, "UNAUTHENTICATED", "metadata is not provided")
     }
 
     values := md["authorization"]
     if len(values) == 0 {
-        return status.Errorf(codes.Unauthenticated, "authorization token is not provided")
+        return newRPCError(codes.Unauthenticated, "UNAUTHENTICATED", "authorization token is not provided")
     }
 
     accessToken := values[0]
     claims, err := interceptor.jwtManager.Verify(accessToken)
     if err != nil {
-        return status.Errorf(codes.Unauthenticated, "invalid token")
+        return newRPCError(codes.Unauthenticated, "INVALID_TOKEN", "invalid token")
     }
 
     for _, role := range accessibleRoles {
@@ -84,5 +83,5 @@ func (interceptor *AuthInterceptor) authorize(ctx context.Context, method string
         }
     }
 
-    return status.Error(codes.PermissionDenied, "you cannot invoke this RPC")
+    return newRPCError(codes.PermissionDenied, "PERMISSION_DENIED", "you cannot invoke this RPC")
 }
@@ -2,10 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
 	"otmane/pcbook/pb"
 
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 // AuthServer is the server for authentication.
@@ -22,17 +22,20 @@ func NewAuthServer(userStore UserStore, jwtManager JWTManager) *AuthServer {
 
 func (server *AuthServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
     user, err := server.userStore.Find(req.GetUsername())
+    if errors.Is(err, ErrNotFound) {
+        return nil, newRPCError(codes.NotFound, "INVALID_CREDENTIALS", "incorrect username/password")
+    }
     if err != nil {
-        return nil, status.Errorf(codes.Internal, "cannot find user: %v", err)
+        return nil, toRPCError(err, resource{}, "cannot find user")
     }
 
     if user == nil || !user.IsCorrectPassword(req.GetPassword()) {
-        return nil, status.Errorf(codes.NotFound, "incorrect username/password")
+        return nil, newRPCError(codes.NotFound, "INVALID_CREDENTIALS", "incorrect username/password")
     }
 
     token, err := server.jwtManager.Generate(user)
     if err != nil {
-        return nil, status.Errorf(codes.Internal, "cannot generate access token")
+        return nil, toRPCError(err, resource{}, "cannot generate access token")
     }
 
     return &pb.LoginResponse{
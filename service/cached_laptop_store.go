@@ -0,0 +1,176 @@
+package service
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"otmane/pcbook/pb"
+)
+
+// CachedLaptopStore is a LaptopStore finding the laptops of another store through a cache of the
+// least recently found ones. Its writes go through to the other store and remove the laptop from
+// the cache, and its searches are not cached.
+type CachedLaptopStore struct {
+	store    LaptopStore
+	capacity int
+	ttl      time.Duration
+	now      func() time.Time
+
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // of *cacheEntry, the most recently used first
+	// writes is the number of writes, a laptop read from the store is cached only if there was none meanwhile
+	writes uint64
+	hits   uint64
+	misses uint64
+}
+
+// cacheEntry is a laptop of the cache with the time it expires at
+type cacheEntry struct {
+	laptop    *pb.Laptop
+	expiresAt time.Time
+}
+
+// CacheStats are the counters of a cache
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+	Size   int
+}
+
+// NewCachedLaptopStore returns a new CachedLaptopStore caching at most capacity laptops of the store,
+// which must be positive, for the ttl or until they are written if the ttl is 0
+func NewCachedLaptopStore(store LaptopStore, capacity int, ttl time.Duration) *CachedLaptopStore {
+	return &CachedLaptopStore{
+		store:    store,
+		capacity: capacity,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// Save saves the laptop to the store
+func (store *CachedLaptopStore) Save(laptop *pb.Laptop) error {
+	defer store.invalidate(laptop.Id)
+
+	return store.store.Save(laptop)
+}
+
+// Find returns the cached laptop with the ID, or finds it in the store and caches it
+func (store *CachedLaptopStore) Find(id string) (*pb.Laptop, error) {
+	laptop, writes := store.cached(id)
+	if laptop != nil {
+		return laptop, nil
+	}
+
+	laptop, err := store.store.Find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	store.add(laptop, writes)
+	return laptop, nil
+}
+
+// Update replaces an existing laptop in the store
+func (store *CachedLaptopStore) Update(laptop *pb.Laptop) error {
+	defer store.invalidate(laptop.Id)
+
+	return store.store.Update(laptop)
+}
+
+// Delete removes a laptop from the store by its ID
+func (store *CachedLaptopStore) Delete(id string, revision uint64) error {
+	defer store.invalidate(id)
+
+	return store.store.Delete(id, revision)
+}
+
+// Search searches for laptops in the store
+func (store *CachedLaptopStore) Search(
+	ctx context.Context,
+	filter *pb.Filter,
+	options SearchOptions,
+	found func(laptop *pb.Laptop) error,
+) (string, error) {
+	return store.store.Search(ctx, filter, options, found)
+}
+
+// Facets counts the laptops of the store matching the filter by value of their facets
+func (store *CachedLaptopStore) Facets(ctx context.Context, filter *pb.Filter, options FacetOptions) (*pb.Facets, error) {
+	return store.store.Facets(ctx, filter, options)
+}
+
+// Stats returns the counters of the cache
+func (store *CachedLaptopStore) Stats() CacheStats {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return CacheStats{
+		Hits:   store.hits,
+		Misses: store.misses,
+		Size:   store.order.Len(),
+	}
+}
+
+// cached returns a copy of the cached laptop with the ID, or nil and the number of writes
+// before reading it from the store
+func (store *CachedLaptopStore) cached(id string) (*pb.Laptop, uint64) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	element := store.entries[id]
+	if element != nil {
+		entry := element.Value.(*cacheEntry)
+		if store.ttl == 0 || store.now().Before(entry.expiresAt) {
+			store.hits++
+			store.order.MoveToFront(element)
+			return deepCopy(entry.laptop), 0
+		}
+
+		store.remove(element)
+	}
+
+	store.misses++
+	return nil, store.writes
+}
+
+// add caches a copy of the laptop read from the store, unless it was written since
+func (store *CachedLaptopStore) add(laptop *pb.Laptop, writes uint64) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.writes != writes || store.entries[laptop.Id] != nil {
+		return
+	}
+
+	entry := &cacheEntry{laptop: deepCopy(laptop)}
+	if store.ttl != 0 {
+		entry.expiresAt = store.now().Add(store.ttl)
+	}
+	store.entries[laptop.Id] = store.order.PushFront(entry)
+
+	if store.order.Len() > store.capacity {
+		store.remove(store.order.Back())
+	}
+}
+
+// invalidate removes the laptop with the ID from the cache once it is written
+func (store *CachedLaptopStore) invalidate(id string) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.writes++
+	if element := store.entries[id]; element != nil {
+		store.remove(element)
+	}
+}
+
+func (store *CachedLaptopStore) remove(element *list.Element) {
+	entry := store.order.Remove(element).(*cacheEntry)
+	delete(store.entries, entry.laptop.Id)
+}
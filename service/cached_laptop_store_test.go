@@ -0,0 +1,128 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"otmane/pcbook/pb"
+	"otmane/pcbook/sample"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCachedLaptopStore(t *testing.T, capacity int, ttl time.Duration) (*CachedLaptopStore, []*pb.Laptop) {
+	memory := NewInMemoryLaptopStore()
+
+	laptops := make([]*pb.Laptop, 3)
+	for i := range laptops {
+		laptops[i] = sample.NewLaptop()
+		require.NoError(t, memory.Save(laptops[i]))
+	}
+
+	return NewCachedLaptopStore(memory, capacity, ttl), laptops
+}
+
+func TestCachedLaptopStoreFind(t *testing.T) {
+	t.Parallel()
+
+	store, laptops := newTestCachedLaptopStore(t, 2, 0)
+
+	found, err := store.Find(laptops[0].Id)
+	require.NoError(t, err)
+	require.Equal(t, CacheStats{Hits: 0, Misses: 1, Size: 1}, store.Stats())
+
+	// the cached laptop is not changed by changing the returned one
+	found.Name = "changed"
+	found, err = store.Find(laptops[0].Id)
+	require.NoError(t, err)
+	require.Equal(t, laptops[0].Name, found.Name)
+	require.Equal(t, CacheStats{Hits: 1, Misses: 1, Size: 1}, store.Stats())
+
+	// missing laptops are not cached
+	_, err = store.Find(sample.NewLaptop().Id)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Equal(t, CacheStats{Hits: 1, Misses: 2, Size: 1}, store.Stats())
+}
+
+func TestCachedLaptopStoreEvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	store, laptops := newTestCachedLaptopStore(t, 2, 0)
+
+	for _, id := range []string{laptops[0].Id, laptops[1].Id, laptops[0].Id, laptops[2].Id} {
+		_, err := store.Find(id)
+		require.NoError(t, err)
+	}
+	require.Equal(t, CacheStats{Hits: 1, Misses: 3, Size: 2}, store.Stats())
+
+	// the second laptop was the least recently used when the third one was cached
+	require.Contains(t, store.entries, laptops[0].Id)
+	require.NotContains(t, store.entries, laptops[1].Id)
+	require.Contains(t, store.entries, laptops[2].Id)
+}
+
+func TestCachedLaptopStoreInvalidatesWrites(t *testing.T) {
+	t.Parallel()
+
+	store, laptops := newTestCachedLaptopStore(t, 2, 0)
+
+	_, err := store.Find(laptops[0].Id)
+	require.NoError(t, err)
+
+	updated := deepCopy(laptops[0])
+	updated.Name = "updated"
+	require.NoError(t, store.Update(updated))
+
+	found, err := store.Find(laptops[0].Id)
+	require.NoError(t, err)
+	require.Equal(t, "updated", found.Name)
+	require.Equal(t, updated.Revision, found.Revision)
+
+	require.NoError(t, store.Delete(laptops[0].Id, 0))
+	_, err = store.Find(laptops[0].Id)
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Equal(t, CacheStats{Hits: 0, Misses: 3, Size: 0}, store.Stats())
+}
+
+func TestCachedLaptopStoreDoesNotCacheStaleReads(t *testing.T) {
+	t.Parallel()
+
+	store, laptops := newTestCachedLaptopStore(t, 2, 0)
+
+	// a laptop read from the store before a write finishes must not be cached after it
+	_, writes := store.cached(laptops[0].Id)
+	stale, err := store.store.Find(laptops[0].Id)
+	require.NoError(t, err)
+
+	updated := deepCopy(laptops[0])
+	updated.Name = "updated"
+	require.NoError(t, store.Update(updated))
+
+	store.add(stale, writes)
+	require.Zero(t, store.Stats().Size)
+
+	found, err := store.Find(laptops[0].Id)
+	require.NoError(t, err)
+	require.Equal(t, "updated", found.Name)
+}
+
+func TestCachedLaptopStoreExpires(t *testing.T) {
+	t.Parallel()
+
+	store, laptops := newTestCachedLaptopStore(t, 2, time.Minute)
+	now := time.Now()
+	store.now = func() time.Time { return now }
+
+	_, err := store.Find(laptops[0].Id)
+	require.NoError(t, err)
+
+	now = now.Add(59 * time.Second)
+	_, err = store.Find(laptops[0].Id)
+	require.NoError(t, err)
+	require.Equal(t, CacheStats{Hits: 1, Misses: 1, Size: 1}, store.Stats())
+
+	now = now.Add(time.Second)
+	_, err = store.Find(laptops[0].Id)
+	require.NoError(t, err)
+	require.Equal(t, CacheStats{Hits: 1, Misses: 2, Size: 1}, store.Stats())
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"otmane/pcbook/pb"
+	"otmane/pcbook/serializer"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	LAPTOP_LOG_FILE      = "laptops.log"
+	LAPTOP_SNAPSHOT_FILE = "laptops.snapshot"
+	// COMPACT_MIN_RECORDS is the number of records the log must reach before being compacted,
+	// it must also have more records than there are laptops in the store
+	COMPACT_MIN_RECORDS = 1000
+)
+
+// FileLaptopStore is a durable laptop store. It keeps the laptops in memory and appends each
+// change to a log in its directory, synced before the change is applied. The log is replayed
+// on startup and compacted into a snapshot of the laptops once it grows large enough.
+type FileLaptopStore struct {
+	mutex  sync.Mutex // serializes the writes to the log
+	memory *InMemoryLaptopStore
+	log    *serializer.DelimitedLog
+}
+
+// NewFileLaptopStore returns a new FileLaptopStore loading the laptops saved in the directory
+func NewFileLaptopStore(dir string) (*FileLaptopStore, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create the data directory: %w", err)
+	}
+
+	store := &FileLaptopStore{
+		memory: NewInMemoryLaptopStore(),
+	}
+
+	store.log, err = serializer.OpenDelimitedLog(
+		filepath.Join(dir, LAPTOP_SNAPSHOT_FILE),
+		filepath.Join(dir, LAPTOP_LOG_FILE),
+		func() proto.Message { return &pb.LaptopRecord{} },
+		func(message proto.Message) error {
+			store.apply(message.(*pb.LaptopRecord))
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load the laptop log: %w", err)
+	}
+
+	return store, nil
+}
+
+func (store *FileLaptopStore) apply(record *pb.LaptopRecord) {
+	switch change := record.GetChange().(type) {
+	case *pb.LaptopRecord_Put:
+		store.memory.put(change.Put)
+	case *pb.LaptopRecord_DeleteId:
+		store.memory.remove(change.DeleteId)
+	}
+}
+
+// write appends the record to the log, then applies it to the store
+func (store *FileLaptopStore) write(record *pb.LaptopRecord) error {
+	err := store.log.Append(record)
+	if err != nil {
+		return fmt.Errorf("cannot write laptop record: %w", err)
+	}
+
+	store.apply(record)
+
+	records := store.log.Records()
+	if records >= COMPACT_MIN_RECORDS && records > store.memory.len() {
+		err := store.compact()
+		if err != nil {
+			// the record is durable in the log, so the write still succeeded
+			log.Printf("cannot compact the laptop log: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// Compact writes a snapshot of the laptops and empties the log
+func (store *FileLaptopStore) Compact() error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.compact()
+}
+
+func (store *FileLaptopStore) compact() error {
+	table := store.memory.snapshot()
+
+	return store.log.Compact(func(writer *serializer.DelimitedFileWriter) error {
+		var err error
+		table.laptops.Ascend(func(laptop *pb.Laptop) bool {
+			err = writer.Write(&pb.LaptopRecord{Change: &pb.LaptopRecord_Put{Put: laptop}})
+			return err == nil
+		})
+		return err
+	})
+}
+
+// Close closes the log of the store
+func (store *FileLaptopStore) Close() error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.log.Close()
+}
+
+// Save saves the laptop to the store
+func (store *FileLaptopStore) Save(laptop *pb.Laptop) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if store.memory.stored(laptop.Id) != nil {
+		return fmt.Errorf("laptop %s: %w", laptop.Id, ErrAlreadyExists)
+	}
+
+	other := deepCopy(laptop)
+	other.Revision = 1
+
+	err := store.write(&pb.LaptopRecord{Change: &pb.LaptopRecord_Put{Put: other}})
+	if err != nil {
+		return err
+	}
+
+	laptop.Revision = other.Revision
+	return nil
+}
+
+// Find searches for a laptop by its ID
+func (store *FileLaptopStore) Find(id string) (*pb.Laptop, error) {
+	return store.memory.Find(id)
+}
+
+// Update replaces an existing laptop in the store and sets its revision to the next one
+func (store *FileLaptopStore) Update(laptop *pb.Laptop) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	stored := store.memory.stored(laptop.Id)
+	err := checkRevision(laptop.Id, stored, laptop.Revision)
+	if err != nil {
+		return err
+	}
+
+	other := deepCopy(laptop)
+	other.Revision = stored.Revision + 1
+
+	err = store.write(&pb.LaptopRecord{Change: &pb.LaptopRecord_Put{Put: other}})
+	if err != nil {
+		return err
+	}
+
+	laptop.Revision = other.Revision
+	return nil
+}
+
+// Delete removes a laptop from the store by its ID
+func (store *FileLaptopStore) Delete(id string, revision uint64) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	err := checkRevision(id, store.memory.stored(id), revision)
+	if err != nil {
+		return err
+	}
+
+	return store.write(&pb.LaptopRecord{Change: &pb.LaptopRecord_DeleteId{DeleteId: id}})
+}
+
+// Search searches for laptops with filter, return one by one via found function in the order of the options
+func (store *FileLaptopStore) Search(
+	ctx context.Context,
+	filter *pb.Filter,
+	options SearchOptions,
+	found func(laptop *pb.Laptop) error,
+) (string, error) {
+	return store.memory.Search(ctx, filter, options, found)
+}
+
+// Facets counts the laptops matching the filter by value of their facets
+func (store *FileLaptopStore) Facets(ctx context.Context, filter *pb.Filter, options FacetOptions) (*pb.Facets, error) {
+	return store.memory.Facets(ctx, filter, options)
+}
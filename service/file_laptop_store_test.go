@@ -0,0 +1,153 @@
+package service_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"otmane/pcbook/pb"
+	"otmane/pcbook/sample"
+	"otmane/pcbook/service"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func searchAll(t *testing.T, store service.LaptopStore) []*pb.Laptop {
+	var laptops []*pb.Laptop
+	_, err := store.Search(context.Background(), nil, service.SearchOptions{}, func(laptop *pb.Laptop) error {
+		laptops = append(laptops, laptop)
+		return nil
+	})
+	require.NoError(t, err)
+
+	return laptops
+}
+
+func TestFileLaptopStoreReopen(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	store, err := service.NewFileLaptopStore(dir)
+	require.NoError(t, err)
+
+	kept := sample.NewLaptop()
+	err = store.Save(kept)
+	require.NoError(t, err)
+
+	kept.Name = "updated"
+	err = store.Update(kept)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, kept.Revision)
+
+	deleted := sample.NewLaptop()
+	err = store.Save(deleted)
+	require.NoError(t, err)
+	err = store.Delete(deleted.Id, 0)
+	require.NoError(t, err)
+
+	err = store.Save(kept)
+	require.ErrorIs(t, err, service.ErrAlreadyExists)
+	err = store.Delete(deleted.Id, 0)
+	require.ErrorIs(t, err, service.ErrNotFound)
+
+	require.NoError(t, store.Close())
+
+	store, err = service.NewFileLaptopStore(dir)
+	require.NoError(t, err)
+	defer store.Close()
+
+	found, err := store.Find(kept.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(kept, found))
+
+	_, err = store.Find(deleted.Id)
+	require.ErrorIs(t, err, service.ErrNotFound)
+	require.Len(t, searchAll(t, store), 1)
+
+	// the revisions survive the restart
+	kept.Revision = 1
+	err = store.Update(kept)
+	require.ErrorIs(t, err, service.ErrRevisionMismatch)
+}
+
+func TestFileLaptopStoreCompact(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	store, err := service.NewFileLaptopStore(dir)
+	require.NoError(t, err)
+
+	var laptops []*pb.Laptop
+	for i := 0; i < service.COMPACT_MIN_RECORDS/2; i++ {
+		laptop := sample.NewLaptop()
+		err = store.Save(laptop)
+		require.NoError(t, err)
+		laptops = append(laptops, laptop)
+	}
+
+	// the updates make the log twice as large as the laptops, which compacts it
+	for _, laptop := range laptops {
+		laptop.PriceUsd++
+		err = store.Update(laptop)
+		require.NoError(t, err)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, service.LAPTOP_LOG_FILE))
+	require.NoError(t, err)
+	require.Zero(t, info.Size())
+
+	err = store.Delete(laptops[0].Id, 0)
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	store, err = service.NewFileLaptopStore(dir)
+	require.NoError(t, err)
+	defer store.Close()
+
+	require.Len(t, searchAll(t, store), len(laptops)-1)
+	for _, laptop := range laptops[1:] {
+		found, err := store.Find(laptop.Id)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(laptop, found))
+	}
+
+	err = store.Compact()
+	require.NoError(t, err)
+}
+
+func TestFileLaptopStoreIncompleteRecord(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	store, err := service.NewFileLaptopStore(dir)
+	require.NoError(t, err)
+
+	laptop := sample.NewLaptop()
+	err = store.Save(laptop)
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	// a crash while appending a record leaves the beginning of its length and content
+	logPath := filepath.Join(dir, service.LAPTOP_LOG_FILE)
+	file, err := os.OpenFile(logPath, os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(t, err)
+	_, err = file.Write([]byte{0x80, 0x01, 0x0a})
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	store, err = service.NewFileLaptopStore(dir)
+	require.NoError(t, err)
+
+	other := sample.NewLaptop()
+	err = store.Save(other)
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	store, err = service.NewFileLaptopStore(dir)
+	require.NoError(t, err)
+	defer store.Close()
+
+	require.Len(t, searchAll(t, store), 2)
+}
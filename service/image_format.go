@@ -0,0 +1,94 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"strings"
+)
+
+// ErrInvalidImage is returned when image data is not in a supported format, or does not match its type
+var ErrInvalidImage = errors.New("invalid image")
+
+// imageExtensions are the file extensions of each supported image format, the first one is used to save them
+var imageExtensions = map[string][]string{
+	"jpeg": {".jpg", ".jpeg"},
+	"png":  {".png"},
+	"gif":  {".gif"},
+	"webp": {".webp"},
+}
+
+// ImageFormats returns the names of the supported image formats, in alphabetical order
+func ImageFormats() []string {
+	return sortedKeys(imageExtensions)
+}
+
+// ParseImageFormats returns the image formats of a comma separated list of their names
+func ParseImageFormats(list string) ([]string, error) {
+	var formats []string
+	for _, name := range strings.Split(list, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if imageExtensions[name] == nil {
+			return nil, fmt.Errorf("unknown image format %q, supported formats are %s", name, strings.Join(ImageFormats(), ", "))
+		}
+		formats = append(formats, name)
+	}
+
+	return formats, nil
+}
+
+// imageFormatOfType returns the format of the image type given by a client, which is a file extension
+func imageFormatOfType(imageType string) (string, bool) {
+	imageType = strings.ToLower(imageType)
+	for format, extensions := range imageExtensions {
+		for _, extension := range extensions {
+			if imageType == extension {
+				return format, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// sniffImageFormat returns the format of the image data, read from its header with the standard image decoders.
+// WebP has no standard decoder, so only its RIFF header is checked.
+func sniffImageFormat(data io.Reader) (string, error) {
+	reader := bufio.NewReader(data)
+
+	header, _ := reader.Peek(12)
+	if len(header) == 12 && bytes.Equal(header[:4], []byte("RIFF")) && bytes.Equal(header[8:], []byte("WEBP")) {
+		return "webp", nil
+	}
+
+	_, format, err := image.DecodeConfig(reader)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if imageExtensions[format] == nil {
+		return "", fmt.Errorf("%w: unsupported format %s", ErrInvalidImage, format)
+	}
+
+	return format, nil
+}
+
+// isSafeImageType reports whether the image type can be the extension of a file name of the image folder
+func isSafeImageType(imageType string) bool {
+	if len(imageType) < 2 || len(imageType) > 8 || imageType[0] != '.' {
+		return false
+	}
+
+	for _, c := range imageType[1:] {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+
+	return true
+}
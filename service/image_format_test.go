@@ -0,0 +1,96 @@
+package service
+
+import (
+	"bytes"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func encodeTestImage(t *testing.T, format string) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+
+	var data bytes.Buffer
+	var err error
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(&data, img, nil)
+	case "png":
+		err = png.Encode(&data, img)
+	case "gif":
+		err = gif.Encode(&data, img, nil)
+	case "webp":
+		_, err = data.WriteString("RIFF\x1a\x00\x00\x00WEBPVP8L\x0d\x00\x00\x00")
+	}
+	require.NoError(t, err)
+
+	return data.Bytes()
+}
+
+func TestImageExtension(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name      string
+		formats   []string
+		imageType string
+		data      []byte
+		extension string
+		field     string
+	}{
+		{name: "jpeg", imageType: ".jpg", data: encodeTestImage(t, "jpeg"), extension: ".jpg"},
+		{name: "jpeg_long_extension", imageType: ".JPEG", data: encodeTestImage(t, "jpeg"), extension: ".jpg"},
+		{name: "png", imageType: ".png", data: encodeTestImage(t, "png"), extension: ".png"},
+		{name: "gif", imageType: ".gif", data: encodeTestImage(t, "gif"), extension: ".gif"},
+		{name: "webp", imageType: ".webp", data: encodeTestImage(t, "webp"), extension: ".webp"},
+		{name: "no_type", data: encodeTestImage(t, "png"), extension: ".png"},
+		{name: "not_an_image", imageType: ".jpg", data: []byte("#!/bin/sh"), field: "chunk_data"},
+		{name: "truncated", imageType: ".png", data: encodeTestImage(t, "png")[:10], field: "chunk_data"},
+		{name: "mismatch", imageType: ".png", data: encodeTestImage(t, "jpeg"), field: "info.image_type"},
+		{name: "path", imageType: "/../../etc/passwd", data: encodeTestImage(t, "png"), field: "info.image_type"},
+		{name: "not_allowed", formats: []string{"jpeg"}, data: encodeTestImage(t, "gif"), field: "chunk_data"},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := NewLaptopServer(nil, nil, nil)
+			if tc.formats != nil {
+				server.ImageFormats = tc.formats
+			}
+
+			extension, err := server.imageExtension(tc.imageType, bytes.NewReader(tc.data))
+			if tc.field == "" {
+				require.NoError(t, err)
+				require.Equal(t, tc.extension, extension)
+				return
+			}
+
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			require.Equal(t, codes.InvalidArgument, st.Code())
+			require.Len(t, st.Details(), 1)
+			require.Equal(t, tc.field, st.Details()[0].(*errdetails.BadRequest).GetFieldViolations()[0].GetField())
+		})
+	}
+}
+
+func TestParseImageFormats(t *testing.T) {
+	t.Parallel()
+
+	formats, err := ParseImageFormats("png, JPEG")
+	require.NoError(t, err)
+	require.Equal(t, []string{"png", "jpeg"}, formats)
+
+	_, err = ParseImageFormats("png,bmp")
+	require.Error(t, err)
+}
@@ -1,69 +1,461 @@
 package service
 
 import (
-	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 	"sync"
+	"time"
+
+	"otmane/pcbook/pb"
+	"otmane/pcbook/serializer"
 
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// IMAGE_INDEX_FILE is the name of the index file in the image folder of a persistent DiskImageStore,
+	// the log of the changes of its images since the index was last compacted into IMAGE_INDEX_SNAPSHOT_FILE
+	IMAGE_INDEX_FILE          = "images.index"
+	IMAGE_INDEX_SNAPSHOT_FILE = IMAGE_INDEX_FILE + ".snapshot"
+	// IMAGE_INDEX_COMPACT_MIN_RECORDS is the number of records the index must reach before being compacted,
+	// it must also have more records than there are images in the store
+	IMAGE_INDEX_COMPACT_MIN_RECORDS = 100
+	// UPLOAD_FILE_PREFIX starts the names of the temporary files images are written to before being saved
+	UPLOAD_FILE_PREFIX = ".upload-"
 )
 
 // ImageStore is an interface to store laptop images
 type ImageStore interface {
 	// Save saves a new laptop image to the store
-	Save(laptopID string, imageType string, imageData bytes.Buffer) (string, error)
+	Save(laptopID string, imageType string, imageData io.Reader) (string, error)
+	// Open returns the info of an image and a reader of its data, which must be closed
+	Open(imageID string) (*ImageInfo, io.ReadCloser, error)
+	// List returns the info of all the images by their ID
+	List() (map[string]*ImageInfo, error)
+	// ListByLaptop returns the info of the images of a laptop by their ID
+	ListByLaptop(laptopID string) (map[string]*ImageInfo, error)
+	// Delete removes an image and its data from the store
+	Delete(imageID string) error
+	// Set replaces the info of an image whose data is already stored, to restore it from a snapshot.
+	// It returns ErrNotFound if the data is missing.
+	Set(imageID string, info *ImageInfo) error
 }
 
-// DiskImageStore stores images on disk and its info on memory
+// DiskImageStore stores images on disk and its info on memory. Once opened with OpenDiskImageStore,
+// each change of the info is also appended to the index file of the image folder.
 type DiskImageStore struct {
-	mutex       sync.RWMutex
+	writes      sync.Mutex   // serializes the changes, held while appending them to the index
+	mutex       sync.RWMutex // held only while reading or changing the images
 	imageFolder string
+	index       *serializer.DelimitedLog // nil if the info is kept on memory only
 	images      map[string]*ImageInfo
+	// laptopImages are the IDs of the images of each laptop
+	laptopImages map[string]map[string]bool
 }
 
 // ImageInfo contains information of the laptop image
 type ImageInfo struct {
-	LaptopID string
-	Type     string
-	Path     string
+	LaptopID  string
+	Type      string
+	Path      string
+	Size      int64
+	Checksum  string // hex encoded SHA-256 of the image data
+	CreatedAt time.Time
+}
+
+// ImageIndexReport lists the differences found between the index of a DiskImageStore and its image folder
+type ImageIndexReport struct {
+	// Orphans are the names of the files of the folder which are not images of the index
+	Orphans []string
+	// Missing are the IDs of the images of the index whose file is missing, they are dropped from it
+	Missing []string
 }
 
-// NewDiskImageStore returns a new DiskImageStore
+// NewDiskImageStore returns a new DiskImageStore keeping the info of its images on memory only
 func NewDiskImageStore(imageFolder string) ImageStore {
+	return newDiskImageStore(imageFolder)
+}
+
+func newDiskImageStore(imageFolder string) *DiskImageStore {
 	return &DiskImageStore{
-		imageFolder: imageFolder,
-		images:      make(map[string]*ImageInfo),
+		imageFolder:  imageFolder,
+		images:       make(map[string]*ImageInfo),
+		laptopImages: make(map[string]map[string]bool),
+	}
+}
+
+// OpenDiskImageStore returns a new DiskImageStore saving the info of its images to the index file
+// of the image folder. The images of the index are loaded and reconciled with the files of the folder.
+func OpenDiskImageStore(imageFolder string) (*DiskImageStore, *ImageIndexReport, error) {
+	err := os.MkdirAll(imageFolder, 0o755)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot create the image folder: %w", err)
+	}
+
+	store := newDiskImageStore(imageFolder)
+	store.index, err = serializer.OpenDelimitedLog(
+		filepath.Join(imageFolder, IMAGE_INDEX_SNAPSHOT_FILE),
+		filepath.Join(imageFolder, IMAGE_INDEX_FILE),
+		func() proto.Message { return &pb.ImageIndexRecord{} },
+		func(message proto.Message) error {
+			return store.applyIndexRecord(message.(*pb.ImageIndexRecord))
+		},
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot load the image index: %w", err)
+	}
+
+	report, err := store.reconcile()
+	if err != nil {
+		store.Close()
+		return nil, nil, err
+	}
+
+	return store, report, nil
+}
+
+// Close closes the index file of the store, if it has one
+func (store *DiskImageStore) Close() error {
+	store.writes.Lock()
+	defer store.writes.Unlock()
+
+	if store.index == nil {
+		return nil
+	}
+
+	return store.index.Close()
+}
+
+// reconcile drops the images whose file is missing from the index, and reports them
+// along with the files of the folder which are not images of the index
+func (store *DiskImageStore) reconcile() (*ImageIndexReport, error) {
+	entries, err := os.ReadDir(store.imageFolder)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the image folder: %w", err)
+	}
+
+	files := make(map[string]bool)
+	for _, entry := range entries {
+		// the index files and the temporary files of its snapshots are not images
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), IMAGE_INDEX_FILE) {
+			continue
+		}
+		// the temporary files of the uploads interrupted by a crash are never saved
+		if strings.HasPrefix(entry.Name(), UPLOAD_FILE_PREFIX) {
+			err := os.Remove(filepath.Join(store.imageFolder, entry.Name()))
+			if err != nil {
+				return nil, fmt.Errorf("cannot remove the interrupted upload: %w", err)
+			}
+			continue
+		}
+		files[entry.Name()] = true
+	}
+
+	report := &ImageIndexReport{}
+	for _, imageID := range sortedKeys(store.images) {
+		name := filepath.Base(store.images[imageID].Path)
+		if !files[name] {
+			report.Missing = append(report.Missing, imageID)
+			store.remove(imageID)
+		}
+		delete(files, name)
+	}
+
+	for name := range files {
+		report.Orphans = append(report.Orphans, name)
+	}
+	sort.Strings(report.Orphans)
+
+	if len(report.Missing) > 0 {
+		err := store.compact()
+		if err != nil {
+			return nil, err
+		}
 	}
+
+	return report, nil
 }
 
-// Save saves a new laptop image to the image store
-func (store *DiskImageStore) Save(laptopID string, imageType string, imageData bytes.Buffer) (string, error) {
+// Save saves a new laptop image to the image store, in a file named after its ID and type. The image data
+// is streamed to a temporary file, which is renamed to the image file only once all of it is written.
+func (store *DiskImageStore) Save(laptopID string, imageType string, imageData io.Reader) (string, error) {
+	if !isSafeImageType(imageType) {
+		return "", fmt.Errorf("image type %q: %w", imageType, ErrInvalidImage)
+	}
+
 	imageID, err := uuid.NewRandom()
 	if err != nil {
 		return "", fmt.Errorf("cannot generate image ID: %w", err)
 	}
 
-	imagePath := fmt.Sprintf("%s/%s%s", store.imageFolder, imageID, imageType)
-
-	file, err := os.Create(imagePath)
+	file, err := os.CreateTemp(store.imageFolder, UPLOAD_FILE_PREFIX+"*")
 	if err != nil {
 		return "", fmt.Errorf("cannot create the image file: %w", err)
 	}
 
-	_, err = imageData.WriteTo(file)
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(file, hash), imageData)
+	if err == nil {
+		err = file.Sync()
+	}
+	if err == nil {
+		err = file.Close()
+	} else {
+		file.Close()
+	}
 	if err != nil {
+		os.Remove(file.Name())
 		return "", fmt.Errorf("cannot write image to file: %w", err)
 	}
 
-	store.mutex.Lock()
-	defer store.mutex.Unlock()
+	imagePath := filepath.Join(store.imageFolder, imageID.String()+imageType)
+
+	err = os.Rename(file.Name(), imagePath)
+	if err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("cannot rename the image file: %w", err)
+	}
 
-	store.images[imageID.String()] = &ImageInfo{
-		LaptopID: laptopID,
-		Type:     imageType,
-		Path:     imagePath,
+	store.writes.Lock()
+	defer store.writes.Unlock()
+
+	err = store.update(putImageRecord(imageID.String(), &ImageInfo{
+		LaptopID:  laptopID,
+		Type:      imageType,
+		Path:      imagePath,
+		Size:      size,
+		Checksum:  hex.EncodeToString(hash.Sum(nil)),
+		CreatedAt: time.Now(),
+	}))
+	if err != nil {
+		os.Remove(imagePath)
+		return "", err
 	}
 
 	return imageID.String(), nil
 }
+
+// Open returns the info of an image and a reader of its file
+func (store *DiskImageStore) Open(imageID string) (*ImageInfo, io.ReadCloser, error) {
+	store.mutex.RLock()
+	info := store.images[imageID]
+	store.mutex.RUnlock()
+
+	if info == nil {
+		return nil, nil, fmt.Errorf("image %s: %w", imageID, ErrNotFound)
+	}
+
+	file, err := os.Open(info.Path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot open the image file: %w", err)
+	}
+
+	other := *info
+	return &other, file, nil
+}
+
+// List returns the info of all the images by their ID
+func (store *DiskImageStore) List() (map[string]*ImageInfo, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	images := make(map[string]*ImageInfo, len(store.images))
+	for imageID, info := range store.images {
+		other := *info
+		images[imageID] = &other
+	}
+
+	return images, nil
+}
+
+// Set replaces the info of an image whose data is already stored in the file of the image folder named
+// after its ID and type, which is its path whatever the path of the info. It returns ErrNotFound if the
+// file is missing, and ErrInvalidImage if the ID or the type cannot name a file of the folder.
+func (store *DiskImageStore) Set(imageID string, info *ImageInfo) error {
+	_, err := uuid.Parse(imageID)
+	if err != nil || !isSafeImageType(info.Type) {
+		return fmt.Errorf("image %q of type %q: %w", imageID, info.Type, ErrInvalidImage)
+	}
+
+	other := *info
+	other.Path = filepath.Join(store.imageFolder, imageID+info.Type)
+
+	stat, err := os.Stat(other.Path)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && !stat.Mode().IsRegular()) {
+		return fmt.Errorf("file of image %s: %w", imageID, ErrNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot check the image file: %w", err)
+	}
+
+	store.writes.Lock()
+	defer store.writes.Unlock()
+
+	return store.update(putImageRecord(imageID, &other))
+}
+
+// ListByLaptop returns the info of the images of a laptop by their ID
+func (store *DiskImageStore) ListByLaptop(laptopID string) (map[string]*ImageInfo, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	images := make(map[string]*ImageInfo, len(store.laptopImages[laptopID]))
+	for imageID := range store.laptopImages[laptopID] {
+		other := *store.images[imageID]
+		images[imageID] = &other
+	}
+
+	return images, nil
+}
+
+// Delete removes the info of an image and then its file. A file which cannot be removed
+// is only logged, it is reported as an orphan the next time the store is opened.
+func (store *DiskImageStore) Delete(imageID string) error {
+	store.writes.Lock()
+	defer store.writes.Unlock()
+
+	store.mutex.RLock()
+	info := store.images[imageID]
+	store.mutex.RUnlock()
+
+	if info == nil {
+		return fmt.Errorf("image %s: %w", imageID, ErrNotFound)
+	}
+
+	err := store.update(&pb.ImageIndexRecord{Change: &pb.ImageIndexRecord_DeleteId{DeleteId: imageID}})
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(info.Path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("cannot remove the file of image %s: %v", imageID, err)
+	}
+
+	return nil
+}
+
+// update appends the change to the index of the store if it has one, then applies it to the images.
+// The writes must be held, the mutex is held only while applying the change.
+func (store *DiskImageStore) update(record *pb.ImageIndexRecord) error {
+	if store.index != nil {
+		err := store.index.Append(record)
+		if err != nil {
+			return fmt.Errorf("cannot write image record: %w", err)
+		}
+	}
+
+	store.mutex.Lock()
+	err := store.applyIndexRecord(record)
+	images := len(store.images)
+	store.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if store.index != nil && store.index.Records() >= IMAGE_INDEX_COMPACT_MIN_RECORDS && store.index.Records() > images {
+		err := store.compact()
+		if err != nil {
+			// the change is durable in the index, so it still succeeded
+			log.Printf("cannot compact the image index: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// compact writes a put of each of the images to the snapshot of the index and empties its log.
+// The writes must be held.
+func (store *DiskImageStore) compact() error {
+	images, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	return store.index.Compact(func(writer *serializer.DelimitedFileWriter) error {
+		for _, imageID := range sortedKeys(images) {
+			err := writer.Write(putImageRecord(imageID, images[imageID]))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// applyIndexRecord applies a change of the index to the images
+func (store *DiskImageStore) applyIndexRecord(record *pb.ImageIndexRecord) error {
+	switch change := record.GetChange().(type) {
+	case *pb.ImageIndexRecord_Put:
+		name := change.Put.GetFileName()
+		if !filepath.IsLocal(name) || filepath.Base(name) != name {
+			return fmt.Errorf("image %s has an invalid file name %q", change.Put.GetId(), name)
+		}
+
+		store.put(change.Put.GetId(), &ImageInfo{
+			LaptopID:  change.Put.GetLaptopId(),
+			Type:      change.Put.GetImageType(),
+			Path:      filepath.Join(store.imageFolder, name),
+			Size:      int64(change.Put.GetSize()),
+			Checksum:  change.Put.GetChecksumSha256(),
+			CreatedAt: change.Put.GetCreatedAt().AsTime(),
+		})
+	case *pb.ImageIndexRecord_DeleteId:
+		store.remove(change.DeleteId)
+	}
+
+	return nil
+}
+
+// putImageRecord returns the change of the index saving the info of an image
+func putImageRecord(imageID string, info *ImageInfo) *pb.ImageIndexRecord {
+	return &pb.ImageIndexRecord{Change: &pb.ImageIndexRecord_Put{Put: newImageRecord(imageID, info)}}
+}
+
+// newImageRecord returns the record of the info of an image saved to the index file
+func newImageRecord(imageID string, info *ImageInfo) *pb.ImageRecord {
+	return &pb.ImageRecord{
+		Id:             imageID,
+		LaptopId:       info.LaptopID,
+		ImageType:      info.Type,
+		FileName:       filepath.Base(info.Path),
+		Size:           uint64(info.Size),
+		ChecksumSha256: info.Checksum,
+		CreatedAt:      timestamppb.New(info.CreatedAt),
+	}
+}
+
+// put adds the info of an image to the store, replacing the one with the same ID if any
+func (store *DiskImageStore) put(imageID string, info *ImageInfo) {
+	store.remove(imageID)
+	store.images[imageID] = info
+
+	if store.laptopImages[info.LaptopID] == nil {
+		store.laptopImages[info.LaptopID] = make(map[string]bool)
+	}
+	store.laptopImages[info.LaptopID][imageID] = true
+}
+
+// remove removes the info of an image from the store if it exists
+func (store *DiskImageStore) remove(imageID string) {
+	info := store.images[imageID]
+	if info == nil {
+		return
+	}
+
+	delete(store.images, imageID)
+	delete(store.laptopImages[info.LaptopID], imageID)
+	if len(store.laptopImages[info.LaptopID]) == 0 {
+		delete(store.laptopImages, info.LaptopID)
+	}
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenDiskImageStoreReloadsIndex(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	store, report, err := OpenDiskImageStore(folder)
+	require.NoError(t, err)
+	require.Equal(t, &ImageIndexReport{}, report)
+
+	imageID, err := store.Save("laptop", ".jpg", strings.NewReader("image"))
+	require.NoError(t, err)
+	deletedID, err := store.Save("laptop", ".png", strings.NewReader("deleted"))
+	require.NoError(t, err)
+	require.NoError(t, store.Delete(deletedID))
+
+	expected, err := store.List()
+	require.NoError(t, err)
+
+	reopened, report, err := OpenDiskImageStore(folder)
+	require.NoError(t, err)
+	require.Equal(t, &ImageIndexReport{}, report)
+
+	images, err := reopened.List()
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	require.Equal(t, expected[imageID].Checksum, images[imageID].Checksum)
+	require.Equal(t, expected[imageID].Size, images[imageID].Size)
+	require.True(t, expected[imageID].CreatedAt.Equal(images[imageID].CreatedAt))
+
+	laptopImages, err := reopened.ListByLaptop("laptop")
+	require.NoError(t, err)
+	require.Contains(t, laptopImages, imageID)
+}
+
+func TestOpenDiskImageStoreReconciles(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	store, _, err := OpenDiskImageStore(folder)
+	require.NoError(t, err)
+
+	imageID, err := store.Save("laptop", ".jpg", strings.NewReader("image"))
+	require.NoError(t, err)
+	missingID, err := store.Save("laptop", ".jpg", strings.NewReader("missing"))
+	require.NoError(t, err)
+
+	images, err := store.List()
+	require.NoError(t, err)
+	require.NoError(t, os.Remove(images[missingID].Path))
+	require.NoError(t, os.WriteFile(filepath.Join(folder, "orphan.jpg"), []byte("orphan"), 0o644))
+	require.NoError(t, os.Mkdir(filepath.Join(folder, "directory"), 0o755))
+	upload := filepath.Join(folder, UPLOAD_FILE_PREFIX+"interrupted")
+	require.NoError(t, os.WriteFile(upload, []byte("partial"), 0o644))
+
+	store, report, err := OpenDiskImageStore(folder)
+	require.NoError(t, err)
+	require.Equal(t, &ImageIndexReport{Orphans: []string{"orphan.jpg"}, Missing: []string{missingID}}, report)
+	require.NoFileExists(t, upload)
+
+	images, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	require.Contains(t, images, imageID)
+
+	// the missing image was dropped from the index, the orphan is reported until it is removed
+	_, report, err = OpenDiskImageStore(folder)
+	require.NoError(t, err)
+	require.Equal(t, &ImageIndexReport{Orphans: []string{"orphan.jpg"}}, report)
+}
+
+func TestDiskImageStoreSaveFailedRead(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	store, _, err := OpenDiskImageStore(folder)
+	require.NoError(t, err)
+
+	// the data of an upload canceled after its first chunk
+	readErr := errors.New("upload canceled")
+	_, err = store.Save("laptop", ".jpg", io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(readErr)))
+	require.ErrorIs(t, err, readErr)
+
+	images, err := store.List()
+	require.NoError(t, err)
+	require.Empty(t, images)
+
+	// the partial file is removed
+	files, err := os.ReadDir(folder)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, IMAGE_INDEX_FILE, files[0].Name())
+}
+
+func TestDiskImageStoreCompactsIndex(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	store, _, err := OpenDiskImageStore(folder)
+	require.NoError(t, err)
+
+	var imageIDs []string
+	for i := 0; i < IMAGE_INDEX_COMPACT_MIN_RECORDS/2; i++ {
+		imageID, err := store.Save("laptop", ".jpg", strings.NewReader("image"))
+		require.NoError(t, err)
+		imageIDs = append(imageIDs, imageID)
+	}
+
+	// the changes make the index twice as large as the images, which compacts it
+	for _, imageID := range imageIDs {
+		require.NoError(t, store.Set(imageID, &ImageInfo{LaptopID: "other", Type: ".jpg"}))
+	}
+	require.Zero(t, store.index.Records())
+	require.FileExists(t, filepath.Join(folder, IMAGE_INDEX_SNAPSHOT_FILE))
+
+	require.NoError(t, store.Delete(imageIDs[0]))
+	expected, err := store.List()
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	store, report, err := OpenDiskImageStore(folder)
+	require.NoError(t, err)
+	defer store.Close()
+
+	require.Equal(t, &ImageIndexReport{}, report)
+	images, err := store.List()
+	require.NoError(t, err)
+	require.Equal(t, len(expected), len(images))
+	for imageID, info := range expected {
+		require.Equal(t, "other", images[imageID].LaptopID)
+		require.Equal(t, info.Path, images[imageID].Path)
+	}
+}
+
+func TestOpenDiskImageStoreIncompleteRecord(t *testing.T) {
+	t.Parallel()
+
+	folder := t.TempDir()
+	store, _, err := OpenDiskImageStore(folder)
+	require.NoError(t, err)
+
+	imageID, err := store.Save("laptop", ".jpg", strings.NewReader("image"))
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	// a crash while appending a record leaves the beginning of its length and content
+	file, err := os.OpenFile(filepath.Join(folder, IMAGE_INDEX_FILE), os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(t, err)
+	_, err = file.Write([]byte{0x80, 0x01, 0x0a})
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	store, _, err = OpenDiskImageStore(folder)
+	require.NoError(t, err)
+	defer store.Close()
+
+	images, err := store.List()
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	require.Contains(t, images, imageID)
+
+	// the incomplete record was dropped, so the next ones are read again
+	_, err = store.Save("laptop", ".jpg", strings.NewReader("image"))
+	require.NoError(t, err)
+	require.NoError(t, store.Close())
+
+	store, _, err = OpenDiskImageStore(folder)
+	require.NoError(t, err)
+	defer store.Close()
+
+	images, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, images, 2)
+}
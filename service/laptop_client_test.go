@@ -2,14 +2,18 @@ package service_test
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"net"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"testing"
 
+	"otmane/pcbook/client"
 	"otmane/pcbook/pb"
 	"otmane/pcbook/sample"
 	"otmane/pcbook/serializer"
@@ -17,7 +21,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 func TestClientCreateLaptop(t *testing.T) {
@@ -44,7 +51,9 @@ func TestClientCreateLaptop(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, other)
 
-	// Check that the stored laptop is the same one we sent
+	// Check that the stored laptop is the same one we sent, at its first revision
+	require.EqualValues(t, 1, other.Revision)
+	laptop.Revision = other.Revision
 	requireSameLaptop(t, laptop, other)
 }
 
@@ -137,14 +146,169 @@ func TestClientUploadImage(t *testing.T) {
 	require.NoError(t, os.Remove(savedImagePath))
 }
 
+func TestClientUploadLargeImage(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	imageFolder := t.TempDir()
+	imageStore := service.NewDiskImageStore(imageFolder)
+
+	laptop := sample.NewLaptop()
+	err := laptopStore.Save(laptop)
+	require.NoError(t, err)
+
+	serverAddress := startTestLaptopServer(t, laptopStore, imageStore, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	wallpaper, err := os.ReadFile("../tmp/wallpaper.jpg")
+	require.NoError(t, err)
+
+	// uploadImage uploads the wallpaper followed by padding, so that the image data has the size
+	uploadImage := func(size int) (*pb.UploadImageResponse, error) {
+		stream, err := laptopClient.UploadImage(context.Background())
+		require.NoError(t, err)
+
+		err = stream.Send(&pb.UploadImageRequest{
+			Data: &pb.UploadImageRequest_Info{Info: &pb.ImageInfo{LaptopId: laptop.GetId(), ImageType: ".jpg"}},
+		})
+		require.NoError(t, err)
+
+		imageData := append(wallpaper, make([]byte, size-len(wallpaper))...)
+		for len(imageData) > 0 {
+			n := min(len(imageData), service.CHUNK_SIZE)
+			err = stream.Send(&pb.UploadImageRequest{Data: &pb.UploadImageRequest_ChunkData{ChunkData: imageData[:n]}})
+			if err == io.EOF {
+				// the server rejected the image, the error is returned by CloseAndRecv
+				break
+			}
+			require.NoError(t, err)
+			imageData = imageData[n:]
+		}
+
+		return stream.CloseAndRecv()
+	}
+
+	res, err := uploadImage(8 << 20)
+	require.NoError(t, err)
+	require.EqualValues(t, 8<<20, res.GetSize())
+
+	info, reader, err := imageStore.Open(res.GetId())
+	require.NoError(t, err)
+	require.NoError(t, reader.Close())
+	require.EqualValues(t, 8<<20, info.Size)
+
+	_, err = uploadImage(service.MAX_ALLOWED_SIZE + 1)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// the rejected image leaves no partial file behind
+	files, err := os.ReadDir(imageFolder)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, res.GetId()+".jpg", files[0].Name())
+}
+
+// deletingImageStore deletes the laptop of each image it saves, as if it was deleted during the upload
+type deletingImageStore struct {
+	service.ImageStore
+	laptopStore service.LaptopStore
+}
+
+func (store deletingImageStore) Save(laptopID string, imageType string, imageData io.Reader) (string, error) {
+	imageID, err := store.ImageStore.Save(laptopID, imageType, imageData)
+	if err != nil {
+		return "", err
+	}
+
+	return imageID, store.laptopStore.Delete(laptopID, 0)
+}
+
+func TestClientUploadImageLaptopDeleted(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	imageStore := service.NewDiskImageStore(t.TempDir())
+
+	laptop := sample.NewLaptop()
+	err := laptopStore.Save(laptop)
+	require.NoError(t, err)
+
+	serverAddress := startTestLaptopServer(t, laptopStore, deletingImageStore{imageStore, laptopStore}, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	wallpaper, err := os.ReadFile("../tmp/wallpaper.jpg")
+	require.NoError(t, err)
+
+	stream, err := laptopClient.UploadImage(context.Background())
+	require.NoError(t, err)
+	err = stream.Send(&pb.UploadImageRequest{
+		Data: &pb.UploadImageRequest_Info{Info: &pb.ImageInfo{LaptopId: laptop.GetId(), ImageType: ".jpg"}},
+	})
+	require.NoError(t, err)
+	err = stream.Send(&pb.UploadImageRequest{Data: &pb.UploadImageRequest_ChunkData{ChunkData: wallpaper}})
+	require.NoError(t, err)
+
+	// the upload fails instead of returning the ID of the deleted image
+	_, err = stream.CloseAndRecv()
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	images, err := imageStore.List()
+	require.NoError(t, err)
+	require.Empty(t, images)
+}
+
+func TestClientDownloadImage(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	imageStore := service.NewDiskImageStore(t.TempDir())
+
+	laptop := sample.NewLaptop()
+	err := laptopStore.Save(laptop)
+	require.NoError(t, err)
+
+	// bigger than a chunk, so that it is streamed in several messages
+	imageData := bytes.Repeat([]byte("pcbook"), service.CHUNK_SIZE/2)
+	imageID, err := imageStore.Save(laptop.GetId(), ".jpg", bytes.NewReader(imageData))
+	require.NoError(t, err)
+
+	serverAddress := startTestLaptopServer(t, laptopStore, imageStore, nil)
+	conn, err := grpc.Dial(serverAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	laptopClient := client.NewLaptopClient(conn)
+	downloadFolder := t.TempDir()
+
+	imagePath, err := laptopClient.DownloadImage(imageID, downloadFolder)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(downloadFolder, imageID+".jpg"), imagePath)
+
+	downloaded, err := os.ReadFile(imagePath)
+	require.NoError(t, err)
+	require.Equal(t, imageData, downloaded)
+
+	_, err = laptopClient.DownloadImage("missing", downloadFolder)
+	require.Error(t, err)
+
+	stream, err := pb.NewLaptopServiceClient(conn).DownloadImage(context.Background(), &pb.DownloadImageRequest{ImageId: "missing"})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// only the downloaded image is left in the folder
+	entries, err := os.ReadDir(downloadFolder)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
 func TestClientSearchLaptop(t *testing.T) {
 	t.Parallel()
 
 	filter := &pb.Filter{
-		MaxPriceUsd: 2000,
-		MinCpuCors:  3,
+		MaxPriceUsd: proto.Float64(2000),
+		MinCpuCors:  proto.Uint32(3),
 		MinRam:      &pb.Memory{Value: 16, Unit: pb.Memory_GIGABYTE},
-		MinCpuGhz:   3,
+		MinCpuGhz:   proto.Float64(3),
 	}
 
 	store := service.NewInMemoryLaptopStore()
@@ -221,6 +385,266 @@ func TestClientSearchLaptop(t *testing.T) {
 	require.Equal(t, len(expectedIds), found)
 }
 
+func TestClientSearchLaptopPagination(t *testing.T) {
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	for i := 0; i < 25; i++ {
+		err := store.Save(sample.NewLaptop())
+		require.NoError(t, err)
+	}
+
+	serverAddress := startTestLaptopServer(t, store, nil, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	var ids []string
+	var pageSizes []int
+	req := &pb.SearchLaptopRequest{
+		PageSize: 10,
+	}
+
+	for {
+		stream, err := laptopClient.SearchLaptop(context.Background(), req)
+		require.NoError(t, err)
+
+		req.PageToken = ""
+		pageSize := 0
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+
+			if res.GetNextPageToken() != "" {
+				req.PageToken = res.GetNextPageToken()
+				continue
+			}
+
+			ids = append(ids, res.GetLaptop().GetId())
+			pageSize++
+		}
+
+		pageSizes = append(pageSizes, pageSize)
+		if req.PageToken == "" {
+			break
+		}
+	}
+
+	require.Equal(t, []int{10, 10, 5}, pageSizes)
+	require.Len(t, ids, 25)
+	require.True(t, sort.StringsAreSorted(ids))
+
+	req.PageToken = "not-a-token"
+	stream, err := laptopClient.SearchLaptop(context.Background(), req)
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestClientSearchLaptopSortBy(t *testing.T) {
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	ratingStore := service.NewInMemoryRatingStore()
+	for i := 0; i < 12; i++ {
+		laptop := sample.NewLaptop()
+		err := store.Save(laptop)
+		require.NoError(t, err)
+
+		// rate a third of the laptops, the others keep a score of 0
+		if i%3 == 0 {
+			_, err = ratingStore.Add(laptop.Id, sample.RandomLaptopScore())
+			require.NoError(t, err)
+		}
+	}
+
+	serverAddress := startTestLaptopServer(t, store, nil, ratingStore)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	req := &pb.SearchLaptopRequest{
+		PageSize: 5,
+		SortBy: []*pb.SortOrder{
+			{Key: pb.SortOrder_RATING, Descending: true},
+			{Key: pb.SortOrder_PRICE_USD},
+		},
+	}
+
+	var laptops []*pb.Laptop
+	for {
+		stream, err := laptopClient.SearchLaptop(context.Background(), req)
+		require.NoError(t, err)
+
+		req.PageToken = ""
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+
+			if res.GetNextPageToken() != "" {
+				req.PageToken = res.GetNextPageToken()
+			} else {
+				laptops = append(laptops, res.GetLaptop())
+			}
+		}
+
+		if req.PageToken == "" {
+			break
+		}
+	}
+
+	require.Len(t, laptops, 12)
+
+	score := func(laptop *pb.Laptop) float64 {
+		rating, err := ratingStore.Find(laptop.Id)
+		if err != nil {
+			return 0
+		}
+		return rating.Sum / float64(rating.Count)
+	}
+	for i := 1; i < len(laptops); i++ {
+		prev, cur := laptops[i-1], laptops[i]
+		require.GreaterOrEqual(t, score(prev), score(cur))
+		if score(prev) == score(cur) {
+			require.LessOrEqual(t, prev.GetPriceUsd(), cur.GetPriceUsd())
+		}
+	}
+
+	req.SortBy = []*pb.SortOrder{{Key: pb.SortOrder_UNKNOWN}}
+	stream, err := laptopClient.SearchLaptop(context.Background(), req)
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestClientSearchLaptopSortByOverflowingRating(t *testing.T) {
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	ratingStore := service.NewInMemoryRatingStore()
+	for i := 0; i < 3; i++ {
+		err := store.Save(sample.NewLaptop())
+		require.NoError(t, err)
+	}
+
+	serverAddress := startTestLaptopServer(t, store, nil, ratingStore)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	stream, err := laptopClient.RateLaptop(context.Background())
+	require.NoError(t, err)
+	err = stream.Send(&pb.RateLaptopRequest{LaptopId: laptop.Id, Score: 1.7e308})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// the sum of two huge scores, saved before the scores were bounded, overflows
+	for i := 0; i < 2; i++ {
+		_, err = ratingStore.Add(laptop.Id, 1.7e308)
+		require.NoError(t, err)
+	}
+
+	req := &pb.SearchLaptopRequest{
+		PageSize: 1,
+		SortBy:   []*pb.SortOrder{{Key: pb.SortOrder_RATING, Descending: true}},
+	}
+
+	found := 0
+	for {
+		stream, err := laptopClient.SearchLaptop(context.Background(), req)
+		require.NoError(t, err)
+
+		req.PageToken = ""
+		for {
+			res, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+
+			if res.GetNextPageToken() != "" {
+				req.PageToken = res.GetNextPageToken()
+			} else {
+				found++
+			}
+		}
+
+		if req.PageToken == "" {
+			break
+		}
+	}
+
+	require.Equal(t, 4, found)
+}
+
+func TestClientSearchLaptopQuery(t *testing.T) {
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+	expectedIds := make(map[string]bool)
+
+	for _, brand := range []string{"Apple", "Dell", "Lenovo"} {
+		laptop := sample.NewLaptop()
+		laptop.Brand = brand
+		laptop.Ram = &pb.Memory{Value: 32, Unit: pb.Memory_GIGABYTE}
+		if brand != "Lenovo" {
+			expectedIds[laptop.Id] = true
+		}
+
+		err := store.Save(laptop)
+		require.NoError(t, err)
+	}
+
+	serverAddress := startTestLaptopServer(t, store, nil, nil)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	req := &pb.SearchLaptopRequest{
+		Query: `brand in ("Apple", "Dell") and ram >= 16GB`,
+	}
+	stream, err := laptopClient.SearchLaptop(context.Background(), req)
+	require.NoError(t, err)
+
+	found := 0
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+
+		require.NoError(t, err)
+		require.Contains(t, expectedIds, res.Laptop.Id)
+		found += 1
+	}
+	require.Equal(t, len(expectedIds), found)
+
+	req.Query = `brand in ("Apple", "Dell") and ram >= 16`
+	stream, err = laptopClient.SearchLaptop(context.Background(), req)
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+	require.Contains(t, st.Message(), "position 41")
+
+	// deeply nested queries are rejected instead of overflowing the stack of the parser
+	for _, nested := range []string{strings.Repeat("(", 100) + "ram >= 16GB" + strings.Repeat(")", 100), strings.Repeat("(", 4<<20-1024)} {
+		req.Query = nested
+		stream, err = laptopClient.SearchLaptop(context.Background(), req)
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	}
+}
+
 func startTestLaptopServer(t *testing.T, store service.LaptopStore, imageStore service.ImageStore, ratingStore service.RatingStore) string {
 	laptopServer := service.NewLaptopServer(store, imageStore, ratingStore)
 
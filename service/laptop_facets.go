@@ -0,0 +1,216 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"otmane/pcbook/pb"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// ErrInvalidFacetOptions is returned when the bounds of a facet histogram are not finite and increasing
+var ErrInvalidFacetOptions = errors.New("invalid facet options")
+
+var (
+	defaultPriceBoundsUsd = []float64{1000, 1500, 2000, 2500, 3000}
+	defaultWeightBoundsKg = []float64{1, 1.5, 2, 2.5, 3}
+	ramBoundsGb           = []float64{4, 8, 16, 32, 64}
+)
+
+const bitsPerGigabyte = 1 << 33
+
+// FacetOptions are the bounds of the facet histograms, the defaults are used when empty
+type FacetOptions struct {
+	PriceBoundsUsd []float64
+	WeightBoundsKg []float64
+}
+
+func (options FacetOptions) validate() error {
+	err := checkIncreasing("price", options.PriceBoundsUsd)
+	if err != nil {
+		return err
+	}
+
+	return checkIncreasing("weight", options.WeightBoundsKg)
+}
+
+func checkIncreasing(name string, bounds []float64) error {
+	for i, bound := range bounds {
+		if math.IsNaN(bound) || math.IsInf(bound, 0) {
+			return fmt.Errorf("%w: %s bound %v is not finite", ErrInvalidFacetOptions, name, bound)
+		}
+		if i > 0 && bound <= bounds[i-1] {
+			return fmt.Errorf("%w: %s bounds are not increasing", ErrInvalidFacetOptions, name)
+		}
+	}
+
+	return nil
+}
+
+// facetCounter counts the laptops of a search by value of their facets
+type facetCounter struct {
+	total           uint32
+	brands          map[string]uint32
+	cpuBrands       map[string]uint32
+	gpuBrands       map[string]uint32
+	ram             *histogram
+	storageDrivers  map[string]uint32
+	panels          map[string]uint32
+	keyboardLayouts map[string]uint32
+	price           *histogram
+	weight          *histogram
+}
+
+func newFacetCounter(options FacetOptions) *facetCounter {
+	priceBounds := options.PriceBoundsUsd
+	if len(priceBounds) == 0 {
+		priceBounds = defaultPriceBoundsUsd
+	}
+
+	weightBounds := options.WeightBoundsKg
+	if len(weightBounds) == 0 {
+		weightBounds = defaultWeightBoundsKg
+	}
+
+	return &facetCounter{
+		brands:          make(map[string]uint32),
+		cpuBrands:       make(map[string]uint32),
+		gpuBrands:       make(map[string]uint32),
+		ram:             newHistogram(ramBoundsGb),
+		storageDrivers:  make(map[string]uint32),
+		panels:          make(map[string]uint32),
+		keyboardLayouts: make(map[string]uint32),
+		price:           newHistogram(priceBounds),
+		weight:          newHistogram(weightBounds),
+	}
+}
+
+// add counts the laptop in each of its facets
+func (counter *facetCounter) add(laptop *pb.Laptop) {
+	counter.total++
+	counter.brands[laptop.GetBrand()]++
+	counter.cpuBrands[laptop.GetCpu().GetBrand()]++
+	counter.ram.add(float64(toBit(laptop.GetRam())) / bitsPerGigabyte)
+	counter.panels[laptop.GetScreen().GetPanel().String()]++
+	counter.keyboardLayouts[laptop.GetKeyboard().GetLayout().String()]++
+	counter.price.add(laptop.GetPriceUsd())
+
+	if weight, ok := weightKg(laptop); ok {
+		counter.weight.add(weight)
+	}
+
+	gpuBrands := make(map[string]bool)
+	for _, gpu := range laptop.GetGpus() {
+		gpuBrands[gpu.GetBrand()] = true
+	}
+	for brand := range gpuBrands {
+		counter.gpuBrands[brand]++
+	}
+
+	drivers := make(map[string]bool)
+	for _, storage := range laptop.GetStorages() {
+		drivers[storage.GetDriver().String()] = true
+	}
+	for driver := range drivers {
+		counter.storageDrivers[driver]++
+	}
+}
+
+// merge adds the counts of the other counter, created with the same options
+func (counter *facetCounter) merge(other *facetCounter) {
+	counter.total += other.total
+	mergeCounts(counter.brands, other.brands)
+	mergeCounts(counter.cpuBrands, other.cpuBrands)
+	mergeCounts(counter.gpuBrands, other.gpuBrands)
+	counter.ram.merge(other.ram)
+	mergeCounts(counter.storageDrivers, other.storageDrivers)
+	mergeCounts(counter.panels, other.panels)
+	mergeCounts(counter.keyboardLayouts, other.keyboardLayouts)
+	counter.price.merge(other.price)
+	counter.weight.merge(other.weight)
+}
+
+func mergeCounts(counts, other map[string]uint32) {
+	for value, count := range other {
+		counts[value] += count
+	}
+}
+
+func (counter *facetCounter) facets() *pb.Facets {
+	return &pb.Facets{
+		Total:           counter.total,
+		Brands:          facetCounts(counter.brands),
+		CpuBrands:       facetCounts(counter.cpuBrands),
+		GpuBrands:       facetCounts(counter.gpuBrands),
+		RamGb:           counter.ram.buckets(),
+		StorageDrivers:  facetCounts(counter.storageDrivers),
+		Panels:          facetCounts(counter.panels),
+		KeyboardLayouts: facetCounts(counter.keyboardLayouts),
+		PriceUsd:        counter.price.buckets(),
+		WeightKg:        counter.weight.buckets(),
+	}
+}
+
+// facetCounts returns the counts ordered from the most to the least frequent value
+func facetCounts(counts map[string]uint32) []*pb.FacetCount {
+	facets := make([]*pb.FacetCount, 0, len(counts))
+	for value, count := range counts {
+		facets = append(facets, &pb.FacetCount{Value: value, Count: count})
+	}
+
+	sort.Slice(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].Value < facets[j].Value
+	})
+
+	return facets
+}
+
+// histogram counts values in the buckets delimited by increasing bounds,
+// a value equal to a bound belongs to the bucket starting at it
+type histogram struct {
+	bounds []float64
+	counts []uint32
+}
+
+func newHistogram(bounds []float64) *histogram {
+	return &histogram{
+		bounds: bounds,
+		counts: make([]uint32, len(bounds)+1),
+	}
+}
+
+func (h *histogram) add(value float64) {
+	i := sort.Search(len(h.bounds), func(i int) bool {
+		return h.bounds[i] > value
+	})
+	h.counts[i]++
+}
+
+// merge adds the counts of the other histogram, which has the same bounds
+func (h *histogram) merge(other *histogram) {
+	for i, count := range other.counts {
+		h.counts[i] += count
+	}
+}
+
+func (h *histogram) buckets() []*pb.HistogramBucket {
+	buckets := make([]*pb.HistogramBucket, len(h.counts))
+	for i, count := range h.counts {
+		bucket := &pb.HistogramBucket{Count: count}
+		if i > 0 {
+			bucket.Min = proto.Float64(h.bounds[i-1])
+		}
+		if i < len(h.bounds) {
+			bucket.Max = proto.Float64(h.bounds[i])
+		}
+		buckets[i] = bucket
+	}
+
+	return buckets
+}
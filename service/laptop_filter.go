@@ -0,0 +1,181 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"otmane/pcbook/pb"
+)
+
+const kgPerLb = 0.45359237
+
+// ErrInvalidFilter is returned when a filter has a criterion which is NaN or infinite
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// validateFilter checks that the criteria of the filter are finite, the stores would not compare
+// the laptops with the other ones the same way
+func validateFilter(filter *pb.Filter) error {
+	if field := nonFiniteField(filter.ProtoReflect()); field != "" {
+		return fmt.Errorf("%w: %s is not a finite number", ErrInvalidFilter, field)
+	}
+
+	return nil
+}
+
+// isQualified reports whether the laptop matches every criterion set in the filter
+func isQualified(filter *pb.Filter, laptop *pb.Laptop) bool {
+	if filter == nil {
+		return true
+	}
+
+	if filter.MaxPriceUsd != nil && laptop.GetPriceUsd() > filter.GetMaxPriceUsd() {
+		return false
+	}
+
+	if filter.MinPriceUsd != nil && laptop.GetPriceUsd() < filter.GetMinPriceUsd() {
+		return false
+	}
+
+	if len(filter.GetBrands()) > 0 && !containsFold(filter.GetBrands(), laptop.GetBrand()) {
+		return false
+	}
+
+	if filter.MinCpuCors != nil && laptop.GetCpu().GetNumberCores() < filter.GetMinCpuCors() {
+		return false
+	}
+
+	if filter.MinCpuGhz != nil && laptop.GetCpu().GetMinGhz() < filter.GetMinCpuGhz() {
+		return false
+	}
+
+	if filter.MinRam != nil && toBit(laptop.GetRam()) < toBit(filter.GetMinRam()) {
+		return false
+	}
+
+	if !isGPUQualified(filter, laptop) {
+		return false
+	}
+
+	if !isStorageQualified(filter, laptop) {
+		return false
+	}
+
+	if !isScreenQualified(filter, laptop.GetScreen()) {
+		return false
+	}
+
+	if filter.KeyboardLayout != nil && laptop.GetKeyboard().GetLayout() != filter.GetKeyboardLayout() {
+		return false
+	}
+
+	if filter.Backlit != nil && laptop.GetKeyboard().GetBacklit() != filter.GetBacklit() {
+		return false
+	}
+
+	if filter.MinReleaseYear != nil && laptop.GetReleaseYear() < filter.GetMinReleaseYear() {
+		return false
+	}
+
+	if filter.MaxReleaseYear != nil && laptop.GetReleaseYear() > filter.GetMaxReleaseYear() {
+		return false
+	}
+
+	if filter.MaxWeightKg != nil {
+		weight, ok := weightKg(laptop)
+		if !ok || weight > filter.GetMaxWeightKg() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isGPUQualified reports whether one GPU of the laptop matches the GPU criteria of the filter
+func isGPUQualified(filter *pb.Filter, laptop *pb.Laptop) bool {
+	if len(filter.GetGpuBrands()) == 0 && filter.GetMinGpuMemory() == nil {
+		return true
+	}
+
+	for _, gpu := range laptop.GetGpus() {
+		if len(filter.GetGpuBrands()) > 0 && !containsFold(filter.GetGpuBrands(), gpu.GetBrand()) {
+			continue
+		}
+		if filter.MinGpuMemory != nil && toBit(gpu.GetMemory()) < toBit(filter.GetMinGpuMemory()) {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+func isStorageQualified(filter *pb.Filter, laptop *pb.Laptop) bool {
+	if filter.GetSsdOnly() {
+		if len(laptop.GetStorages()) == 0 {
+			return false
+		}
+
+		for _, storage := range laptop.GetStorages() {
+			if storage.GetDriver() != pb.Storage_SSD {
+				return false
+			}
+		}
+	}
+
+	return filter.GetMinStorage() == nil || totalStorageBits(laptop) >= toBit(filter.GetMinStorage())
+}
+
+func isScreenQualified(filter *pb.Filter, screen *pb.Screen) bool {
+	if filter.MinScreenSizeInch != nil && screen.GetSizeInch() < filter.GetMinScreenSizeInch() {
+		return false
+	}
+
+	if filter.MaxScreenSizeInch != nil && screen.GetSizeInch() > filter.GetMaxScreenSizeInch() {
+		return false
+	}
+
+	if filter.MinResolution != nil &&
+		(screen.GetResolution().GetWidth() < filter.GetMinResolution().GetWidth() ||
+			screen.GetResolution().GetHeight() < filter.GetMinResolution().GetHeight()) {
+		return false
+	}
+
+	if filter.Panel != nil && screen.GetPanel() != filter.GetPanel() {
+		return false
+	}
+
+	return true
+}
+
+func totalStorageBits(laptop *pb.Laptop) uint64 {
+	var total uint64
+	for _, storage := range laptop.GetStorages() {
+		total += toBit(storage.GetMemory())
+	}
+
+	return total
+}
+
+// weightKg returns the weight of the laptop in kilograms, and false if the laptop has no weight
+func weightKg(laptop *pb.Laptop) (float64, bool) {
+	switch weight := laptop.GetWeight().(type) {
+	case *pb.Laptop_WeightKg:
+		return weight.WeightKg, true
+	case *pb.Laptop_WeightLb:
+		return weight.WeightLb * kgPerLb, true
+	default:
+		return 0, false
+	}
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
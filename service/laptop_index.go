@@ -0,0 +1,307 @@
+package service
+
+import (
+	"math"
+	"strings"
+
+	"otmane/pcbook/pb"
+
+	"github.com/google/btree"
+)
+
+const indexDegree = 32
+
+// scanCostRatio is how many laptops a full scan checks in the time of an index lookup and check
+const scanCostRatio = 3
+
+// indexEntry is an entry of a secondary index, ordered by text, then key, then laptop ID.
+// It points to the laptop, which is never modified, to look it up without another search.
+type indexEntry struct {
+	text   string
+	key    float64
+	id     string
+	laptop *pb.Laptop
+}
+
+func lessIndexEntry(a, b indexEntry) bool {
+	if a.text != b.text {
+		return a.text < b.text
+	}
+	if a.key != b.key {
+		return a.key < b.key
+	}
+	return a.id < b.id
+}
+
+// indexRange selects the entries with the text and a key between min and max, both inclusive
+type indexRange struct {
+	text string
+	min  float64
+	max  float64
+}
+
+func keyRange(min, max float64) indexRange {
+	return indexRange{min: min, max: max}
+}
+
+// secondaryIndex is a sorted index of the laptops on one of their fields
+type secondaryIndex struct {
+	entry func(laptop *pb.Laptop) indexEntry
+	tree  *btree.BTreeG[indexEntry]
+}
+
+func newSecondaryIndex(entry func(laptop *pb.Laptop) indexEntry) *secondaryIndex {
+	return &secondaryIndex{
+		entry: entry,
+		tree:  btree.NewG(indexDegree, lessIndexEntry),
+	}
+}
+
+func newKeyIndex(key func(laptop *pb.Laptop) float64) *secondaryIndex {
+	return newSecondaryIndex(func(laptop *pb.Laptop) indexEntry {
+		return indexEntry{key: key(laptop)}
+	})
+}
+
+func (index *secondaryIndex) clone() *secondaryIndex {
+	return &secondaryIndex{entry: index.entry, tree: index.tree.Clone()}
+}
+
+func (index *secondaryIndex) add(laptop *pb.Laptop) {
+	entry := index.entry(laptop)
+	entry.id = laptop.GetId()
+	entry.laptop = laptop
+	index.tree.ReplaceOrInsert(entry)
+}
+
+func (index *secondaryIndex) remove(laptop *pb.Laptop) {
+	entry := index.entry(laptop)
+	entry.id = laptop.GetId()
+	index.tree.Delete(entry)
+}
+
+// ascend calls visit with each laptop in the ranges, until visit returns false
+func (index *secondaryIndex) ascend(ranges []indexRange, visit func(laptop *pb.Laptop) bool) {
+	for _, r := range ranges {
+		stopped := false
+		index.tree.AscendGreaterOrEqual(indexEntry{text: r.text, key: r.min}, func(entry indexEntry) bool {
+			if entry.text != r.text || entry.key > r.max {
+				return false
+			}
+
+			stopped = !visit(entry.laptop)
+			return !stopped
+		})
+
+		if stopped {
+			return
+		}
+	}
+}
+
+// count returns the number of laptops in the ranges, counting up to limit
+func (index *secondaryIndex) count(ranges []indexRange, limit int) int {
+	n := 0
+	index.ascend(ranges, func(laptop *pb.Laptop) bool {
+		n++
+		return n < limit
+	})
+
+	return n
+}
+
+// indexScan is a scan of the ranges of an index selecting a superset of the laptops of a filter
+type indexScan struct {
+	index  *secondaryIndex
+	ranges []indexRange
+}
+
+// laptopIndexes are the secondary indexes of the laptop store
+type laptopIndexes struct {
+	price *secondaryIndex
+	year  *secondaryIndex
+	ram   *secondaryIndex
+	cores *secondaryIndex
+	ghz   *secondaryIndex
+	brand *secondaryIndex
+}
+
+func newLaptopIndexes() *laptopIndexes {
+	return &laptopIndexes{
+		price: newKeyIndex((*pb.Laptop).GetPriceUsd),
+		year: newKeyIndex(func(laptop *pb.Laptop) float64 {
+			return float64(laptop.GetReleaseYear())
+		}),
+		ram: newKeyIndex(func(laptop *pb.Laptop) float64 {
+			return float64(toBit(laptop.GetRam()))
+		}),
+		cores: newKeyIndex(func(laptop *pb.Laptop) float64 {
+			return float64(laptop.GetCpu().GetNumberCores())
+		}),
+		ghz: newKeyIndex(func(laptop *pb.Laptop) float64 {
+			return laptop.GetCpu().GetMinGhz()
+		}),
+		brand: newSecondaryIndex(func(laptop *pb.Laptop) indexEntry {
+			return indexEntry{text: strings.ToLower(laptop.GetBrand())}
+		}),
+	}
+}
+
+func (indexes *laptopIndexes) clone() *laptopIndexes {
+	return &laptopIndexes{
+		price: indexes.price.clone(),
+		year:  indexes.year.clone(),
+		ram:   indexes.ram.clone(),
+		cores: indexes.cores.clone(),
+		ghz:   indexes.ghz.clone(),
+		brand: indexes.brand.clone(),
+	}
+}
+
+func (indexes *laptopIndexes) all() []*secondaryIndex {
+	return []*secondaryIndex{indexes.price, indexes.year, indexes.ram, indexes.cores, indexes.ghz, indexes.brand}
+}
+
+func (indexes *laptopIndexes) add(laptop *pb.Laptop) {
+	for _, index := range indexes.all() {
+		index.add(laptop)
+	}
+}
+
+func (indexes *laptopIndexes) remove(laptop *pb.Laptop) {
+	for _, index := range indexes.all() {
+		index.remove(laptop)
+	}
+}
+
+// scans returns the index scans that can select the laptops matching the criteria set in the filter
+func (indexes *laptopIndexes) scans(filter *pb.Filter) []indexScan {
+	if filter == nil {
+		return nil
+	}
+
+	var scans []indexScan
+	add := func(index *secondaryIndex, ranges ...indexRange) {
+		scans = append(scans, indexScan{index: index, ranges: ranges})
+	}
+
+	if filter.MinPriceUsd != nil || filter.MaxPriceUsd != nil {
+		add(indexes.price, keyRange(optionalMin(filter.MinPriceUsd), optionalMax(filter.MaxPriceUsd)))
+	}
+
+	if filter.MinReleaseYear != nil || filter.MaxReleaseYear != nil {
+		min, max := math.Inf(-1), math.Inf(1)
+		if filter.MinReleaseYear != nil {
+			min = float64(filter.GetMinReleaseYear())
+		}
+		if filter.MaxReleaseYear != nil {
+			max = float64(filter.GetMaxReleaseYear())
+		}
+		add(indexes.year, keyRange(min, max))
+	}
+
+	if filter.MinRam != nil {
+		add(indexes.ram, keyRange(float64(toBit(filter.GetMinRam())), math.Inf(1)))
+	}
+
+	if filter.MinCpuCors != nil {
+		add(indexes.cores, keyRange(float64(filter.GetMinCpuCors()), math.Inf(1)))
+	}
+
+	if filter.MinCpuGhz != nil {
+		add(indexes.ghz, keyRange(filter.GetMinCpuGhz(), math.Inf(1)))
+	}
+
+	if len(filter.GetBrands()) > 0 {
+		seen := make(map[string]bool)
+		var ranges []indexRange
+		for _, brand := range filter.GetBrands() {
+			text := strings.ToLower(brand)
+			if !seen[text] {
+				seen[text] = true
+				ranges = append(ranges, indexRange{text: text, min: math.Inf(-1), max: math.Inf(1)})
+			}
+		}
+		add(indexes.brand, ranges...)
+	}
+
+	return scans
+}
+
+func optionalMin(value *float64) float64 {
+	if value == nil {
+		return math.Inf(-1)
+	}
+	return *value
+}
+
+func optionalMax(value *float64) float64 {
+	if value == nil {
+		return math.Inf(1)
+	}
+	return *value
+}
+
+// plan returns the laptops that may match the filter and the text relevance, selected
+// with the most selective index or the relevance itself, or false when scanning every laptop is cheaper
+func (table *laptopTable) plan(filter *pb.Filter, relevance map[string]float64) ([]*pb.Laptop, bool) {
+	limit := table.laptops.Len() / scanCostRatio
+	useRelevance := relevance != nil && len(relevance) < limit
+	if useRelevance {
+		limit = len(relevance)
+	}
+
+	var best *indexScan
+	for _, scan := range table.indexes.scans(filter) {
+		if n := scan.index.count(scan.ranges, limit); n < limit {
+			limit = n
+			best = &indexScan{index: scan.index, ranges: scan.ranges}
+		}
+	}
+
+	laptops := make([]*pb.Laptop, 0, limit)
+	switch {
+	case best != nil:
+		best.index.ascend(best.ranges, func(laptop *pb.Laptop) bool {
+			laptops = append(laptops, laptop)
+			return true
+		})
+	case useRelevance:
+		for id := range relevance {
+			laptops = append(laptops, table.get(id))
+		}
+	default:
+		return nil, false
+	}
+
+	return laptops, true
+}
+
+// forEachCandidate calls visit with each laptop that may match the filter and the text relevance,
+// looking them up with the query planner unless planned is false
+func (table *laptopTable) forEachCandidate(
+	filter *pb.Filter,
+	relevance map[string]float64,
+	planned bool,
+	visit func(laptop *pb.Laptop) error,
+) error {
+	if planned {
+		if laptops, ok := table.plan(filter, relevance); ok {
+			for _, laptop := range laptops {
+				err := visit(laptop)
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	var err error
+	table.laptops.Ascend(func(laptop *pb.Laptop) bool {
+		err = visit(laptop)
+		return err == nil
+	})
+
+	return err
+}
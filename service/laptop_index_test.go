@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"otmane/pcbook/pb"
+	"otmane/pcbook/sample"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+var plannedFilters = []struct {
+	name   string
+	filter *pb.Filter
+}{
+	{"none", nil},
+	{"price range", &pb.Filter{MinPriceUsd: proto.Float64(2000), MaxPriceUsd: proto.Float64(2200)}},
+	{"max price", &pb.Filter{MaxPriceUsd: proto.Float64(1600)}},
+	{"release year", &pb.Filter{MinReleaseYear: proto.Uint32(2019)}},
+	{"ram", &pb.Filter{MinRam: &pb.Memory{Value: 48, Unit: pb.Memory_GIGABYTE}}},
+	{"cpu", &pb.Filter{MinCpuCors: proto.Uint32(6), MinCpuGhz: proto.Float64(3)}},
+	{"brands", &pb.Filter{Brands: []string{"apple", "Dell", "Apple"}}},
+	{"combined", &pb.Filter{
+		Brands:         []string{"Lenovo"},
+		MaxPriceUsd:    proto.Float64(2500),
+		MinCpuCors:     proto.Uint32(4),
+		MinRam:         &pb.Memory{Value: 16, Unit: pb.Memory_GIGABYTE},
+		MaxWeightKg:    proto.Float64(2),
+		MinCpuGhz:      proto.Float64(2.5),
+		MinPriceUsd:    proto.Float64(1800),
+		KeyboardLayout: pb.Keyboard_QWERTY.Enum(),
+	}},
+}
+
+func newFilledLaptopStore(tb testing.TB, n int) *InMemoryLaptopStore {
+	store := NewInMemoryLaptopStore()
+	for i := 0; i < n; i++ {
+		err := store.Save(sample.NewLaptop())
+		require.NoError(tb, err)
+	}
+
+	return store
+}
+
+func hitIDs(hits []searchHit) []string {
+	ids := make([]string, len(hits))
+	for i, hit := range hits {
+		ids[i] = hit.laptop.GetId()
+	}
+
+	return ids
+}
+
+func TestInMemoryLaptopStorePlannedSearch(t *testing.T) {
+	t.Parallel()
+
+	store := newFilledLaptopStore(t, 500)
+
+	// updates and deletes must keep the indexes in sync with the laptops
+	var laptops []*pb.Laptop
+	store.table.laptops.Ascend(func(laptop *pb.Laptop) bool {
+		laptops = append(laptops, laptop)
+		return true
+	})
+
+	for _, laptop := range laptops {
+		if laptop.GetPriceUsd() < 2000 {
+			err := store.Delete(laptop.GetId(), 0)
+			require.NoError(t, err)
+			continue
+		}
+
+		other := sample.NewLaptop()
+		other.Id = laptop.GetId()
+		err := store.Update(other)
+		require.NoError(t, err)
+	}
+
+	for _, tc := range plannedFilters {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			options := SearchOptions{SortBy: []*pb.SortOrder{{Key: pb.SortOrder_PRICE_USD}}}
+
+			planned, err := store.table.searchHits(context.Background(), tc.filter, options, nil, true)
+			require.NoError(t, err)
+			sortHits(planned, options)
+
+			scanned, err := store.table.searchHits(context.Background(), tc.filter, options, nil, false)
+			require.NoError(t, err)
+			sortHits(scanned, options)
+
+			require.Equal(t, hitIDs(scanned), hitIDs(planned))
+		})
+	}
+}
+
+func BenchmarkInMemoryLaptopStoreSearch(b *testing.B) {
+	for _, n := range []int{1000, 10000, 50000} {
+		store := newFilledLaptopStore(b, n)
+
+		for _, tc := range plannedFilters[1:] {
+			for _, planned := range []bool{false, true} {
+				mode := "scan"
+				if planned {
+					mode = "index"
+				}
+
+				b.Run(fmt.Sprintf("%d/%s/%s", n, tc.name, mode), func(b *testing.B) {
+					options := SearchOptions{}
+					for i := 0; i < b.N; i++ {
+						_, err := store.table.searchHits(context.Background(), tc.filter, options, nil, planned)
+						if err != nil {
+							b.Fatal(err)
+						}
+					}
+				})
+			}
+		}
+	}
+}
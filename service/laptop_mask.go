@@ -0,0 +1,148 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"otmane/pcbook/pb"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// immutableLaptopFields are the laptop fields that cannot be changed with an update mask
+var immutableLaptopFields = map[string]bool{
+	"id":         true,
+	"updated_at": true,
+	"revision":   true,
+}
+
+// validateLaptopMask checks that every path of the mask refers to an updatable laptop field
+func validateLaptopMask(mask *fieldmaskpb.FieldMask) error {
+	var invalid []string
+
+	desc := (&pb.Laptop{}).ProtoReflect().Descriptor()
+	for _, path := range mask.GetPaths() {
+		if immutableLaptopFields[path] || !isValidMaskPath(desc, path) {
+			invalid = append(invalid, path)
+		}
+	}
+
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid field mask paths: %s", strings.Join(invalid, ", "))
+	}
+
+	return nil
+}
+
+func isValidMaskPath(desc protoreflect.MessageDescriptor, path string) bool {
+	names := strings.Split(path, ".")
+
+	for i, name := range names {
+		last := i == len(names)-1
+
+		if last && desc.Oneofs().ByName(protoreflect.Name(name)) != nil {
+			return true
+		}
+
+		field := desc.Fields().ByName(protoreflect.Name(name))
+		if field == nil {
+			return false
+		}
+		if last {
+			return true
+		}
+
+		// only singular message fields can be traversed
+		if field.Message() == nil || field.IsList() || field.IsMap() {
+			return false
+		}
+		desc = field.Message()
+	}
+
+	return false
+}
+
+// applyLaptopMask copies the fields listed in the mask from src into dst.
+// A field that is not set in src is cleared in dst.
+func applyLaptopMask(dst, src *pb.Laptop, mask *fieldmaskpb.FieldMask) {
+	for _, path := range mask.GetPaths() {
+		applyMaskPath(dst.ProtoReflect(), src.ProtoReflect(), strings.Split(path, "."))
+	}
+}
+
+func applyMaskPath(dst, src protoreflect.Message, names []string) {
+	desc := dst.Descriptor()
+	name := protoreflect.Name(names[0])
+
+	if len(names) == 1 {
+		if oneof := desc.Oneofs().ByName(name); oneof != nil {
+			if field := dst.WhichOneof(oneof); field != nil {
+				dst.Clear(field)
+			}
+			if field := src.WhichOneof(oneof); field != nil {
+				dst.Set(field, src.Get(field))
+			}
+			return
+		}
+
+		field := desc.Fields().ByName(name)
+		if src.Has(field) {
+			dst.Set(field, src.Get(field))
+		} else {
+			dst.Clear(field)
+		}
+		return
+	}
+
+	field := desc.Fields().ByName(name)
+	applyMaskPath(dst.Mutable(field).Message(), src.Get(field).Message(), names[1:])
+}
+
+// nonFiniteField returns the path of the first float field of the message which is NaN or infinite,
+// or an empty string if there is none. Such values cannot be ordered nor encoded in page tokens.
+func nonFiniteField(m protoreflect.Message) string {
+	var path string
+
+	m.Range(func(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		name := string(field.Name())
+
+		switch {
+		case field.IsMap():
+		case field.IsList() && field.Message() != nil:
+			list := value.List()
+			for i := 0; i < list.Len() && path == ""; i++ {
+				if inner := nonFiniteField(list.Get(i).Message()); inner != "" {
+					path = fmt.Sprintf("%s[%d].%s", name, i, inner)
+				}
+			}
+		case field.IsList():
+			list := value.List()
+			for i := 0; i < list.Len() && path == ""; i++ {
+				if isNonFinite(field, list.Get(i)) {
+					path = fmt.Sprintf("%s[%d]", name, i)
+				}
+			}
+		case field.Message() != nil:
+			if inner := nonFiniteField(value.Message()); inner != "" {
+				path = name + "." + inner
+			}
+		case isNonFinite(field, value):
+			path = name
+		}
+
+		return path == ""
+	})
+
+	return path
+}
+
+func isNonFinite(field protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+	if field.Kind() != protoreflect.DoubleKind && field.Kind() != protoreflect.FloatKind {
+		return false
+	}
+
+	f := value.Float()
+	return math.IsNaN(f) || math.IsInf(f, 0)
+}
@@ -0,0 +1,245 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"otmane/pcbook/pb"
+	"otmane/pcbook/query"
+)
+
+// ErrInvalidPageToken is returned when a search is given a page token it did not issue
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// ErrInvalidSortOrder is returned when a search is given a sort order with an unknown key
+var ErrInvalidSortOrder = errors.New("invalid sort order")
+
+// SearchOptions controls the selection, the order and the paging of a laptop search
+type SearchOptions struct {
+	// Query selects the laptops in addition to the filter, nil selects every laptop
+	Query query.Predicate
+	// Text selects the laptops containing all of its words, empty selects every laptop
+	Text string
+	// SortBy are the keys to order the laptops by, ties are ordered by relevance
+	// to the text if any, and then by ID
+	SortBy []*pb.SortOrder
+	// Ratings is used to get the average score of the laptops when sorting by rating
+	Ratings RatingStore
+	// PageSize is the maximum number of laptops to return, 0 means no limit
+	PageSize int
+	// PageToken is the token returned by a previous search to continue after its last laptop
+	PageToken string
+}
+
+// validate checks the sort orders and decodes the page token of the options
+func (options SearchOptions) validate() (*pageCursor, error) {
+	for _, order := range options.SortBy {
+		if _, ok := pb.SortOrder_Key_name[int32(order.GetKey())]; !ok || order.GetKey() == pb.SortOrder_UNKNOWN {
+			return nil, fmt.Errorf("%w: unknown key %v", ErrInvalidSortOrder, order.GetKey())
+		}
+	}
+
+	cursor, err := decodePageToken(options.PageToken)
+	if err != nil {
+		return nil, err
+	}
+	if cursor != nil && len(cursor.Keys) != len(options.sortOrders()) {
+		return nil, fmt.Errorf("%w: issued for another sort order", ErrInvalidPageToken)
+	}
+
+	return cursor, nil
+}
+
+// matches reports whether the laptop is selected by both the filter and the query of the options
+func (options SearchOptions) matches(filter *pb.Filter, laptop *pb.Laptop) bool {
+	return isQualified(filter, laptop) && (options.Query == nil || options.Query(laptop))
+}
+
+// sortOrders returns the sort orders of the options, followed by the relevance when searching a text
+func (options SearchOptions) sortOrders() []*pb.SortOrder {
+	if options.Text == "" {
+		return options.SortBy
+	}
+
+	for _, order := range options.SortBy {
+		if order.GetKey() == pb.SortOrder_RELEVANCE {
+			return options.SortBy
+		}
+	}
+
+	relevance := &pb.SortOrder{Key: pb.SortOrder_RELEVANCE, Descending: true}
+	return append(options.SortBy[:len(options.SortBy):len(options.SortBy)], relevance)
+}
+
+// searchHit is a laptop matching a search with the values it is sorted by
+type searchHit struct {
+	laptop *pb.Laptop
+	keys   []float64
+}
+
+// newSearchHit returns the hit of a laptop, relevance is its score for the text of the options
+func newSearchHit(laptop *pb.Laptop, relevance float64, options SearchOptions) searchHit {
+	orders := options.sortOrders()
+
+	keys := make([]float64, len(orders))
+	for i, order := range orders {
+		if order.GetKey() == pb.SortOrder_RELEVANCE {
+			keys[i] = relevance
+		} else {
+			keys[i] = finiteKey(sortKey(laptop, order.GetKey(), options.Ratings))
+		}
+	}
+
+	return searchHit{laptop: laptop, keys: keys}
+}
+
+// finiteKey returns the key as it is if it is finite, the closest finite value if it is infinite, and 0 if
+// it is NaN, so that a laptop saved before the values were checked can still be ordered and paged through
+func finiteKey(key float64) float64 {
+	switch {
+	case math.IsNaN(key):
+		return 0
+	case math.IsInf(key, 1):
+		return math.MaxFloat64
+	case math.IsInf(key, -1):
+		return -math.MaxFloat64
+	default:
+		return key
+	}
+}
+
+func sortKey(laptop *pb.Laptop, key pb.SortOrder_Key, ratings RatingStore) float64 {
+	switch key {
+	case pb.SortOrder_PRICE_USD:
+		return laptop.GetPriceUsd()
+	case pb.SortOrder_RELEASE_YEAR:
+		return float64(laptop.GetReleaseYear())
+	case pb.SortOrder_CPU_MAX_GHZ:
+		return laptop.GetCpu().GetMaxGhz()
+	case pb.SortOrder_RAM:
+		return float64(toBit(laptop.GetRam()))
+	case pb.SortOrder_RATING:
+		return averageScore(ratings, laptop.GetId())
+	default:
+		return 0
+	}
+}
+
+// averageScore returns the average score of a laptop, or 0 if it is not rated yet
+func averageScore(ratings RatingStore, laptopID string) float64 {
+	if ratings == nil {
+		return 0
+	}
+
+	rating, err := ratings.Find(laptopID)
+	if err != nil || rating.Count == 0 {
+		return 0
+	}
+
+	// a sum which overflowed, with ratings saved before the scores were bounded, counts as not rated
+	average := rating.Sum / float64(rating.Count)
+	if math.IsNaN(average) || math.IsInf(average, 0) {
+		return 0
+	}
+
+	return average
+}
+
+// compareHits orders two hits by the sort keys of the options and then by ID
+func compareHits(keys1 []float64, id1 string, keys2 []float64, id2 string, options SearchOptions) int {
+	for i, order := range options.sortOrders() {
+		if keys1[i] == keys2[i] {
+			continue
+		}
+
+		less := keys1[i] < keys2[i]
+		if order.GetDescending() {
+			less = !less
+		}
+		if less {
+			return -1
+		}
+		return 1
+	}
+
+	switch {
+	case id1 < id2:
+		return -1
+	case id1 > id2:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// isAfter reports whether the hit comes after the cursor of a previous page
+func (hit searchHit) isAfter(cursor *pageCursor, options SearchOptions) bool {
+	return cursor == nil || compareHits(hit.keys, hit.laptop.GetId(), cursor.Keys, cursor.ID, options) > 0
+}
+
+func sortHits(hits []searchHit, options SearchOptions) {
+	sort.Slice(hits, func(i, j int) bool {
+		return compareHits(hits[i].keys, hits[i].laptop.GetId(), hits[j].keys, hits[j].laptop.GetId(), options) < 0
+	})
+}
+
+// sendPage calls found for the first page of the sorted hits and returns the token of the next page
+func sendPage(hits []searchHit, options SearchOptions, found func(laptop *pb.Laptop) error) (string, error) {
+	for i, hit := range hits {
+		if options.PageSize > 0 && i == options.PageSize {
+			last := hits[i-1]
+			return encodePageToken(pageCursor{Keys: last.keys, ID: last.laptop.GetId()})
+		}
+
+		err := found(deepCopy(hit.laptop))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return "", nil
+}
+
+// pageCursor is the position of the last laptop of a page, encoded in the page token
+type pageCursor struct {
+	Keys []float64 `json:"keys,omitempty"`
+	ID   string    `json:"id"`
+}
+
+func encodePageToken(cursor pageCursor) (string, error) {
+	for _, key := range cursor.Keys {
+		if math.IsNaN(key) || math.IsInf(key, 0) {
+			return "", fmt.Errorf("cannot encode page token: sort key %v is not finite", key)
+		}
+	}
+
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("cannot encode page token: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodePageToken(token string) (*pageCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+	}
+
+	cursor := &pageCursor{}
+	err = json.Unmarshal(data, cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+	}
+
+	return cursor, nil
+}
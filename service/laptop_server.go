@@ -1,21 +1,32 @@
 package service
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"errors"
 	"io"
 	"log"
+	"slices"
+	"strings"
+	"sync"
 
 	"otmane/pcbook/pb"
+	"otmane/pcbook/query"
 
 	"github.com/google/uuid"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
-	MAX_ALLOWED_SIZE = 1 << 20 // 1MB
+	MAX_ALLOWED_SIZE  = 32 << 20 // 32MB
+	CHUNK_SIZE        = 1 << 16  // 64KB
+	IMAGE_HEADER_SIZE = 1 << 18  // 256KB, the start of the image data its format is detected from
+
+	DEFAULT_PAGE_SIZE = 50
+	MAX_PAGE_SIZE     = 1000
+
+	MAX_SCORE = 10 // scores range from 0 to MAX_SCORE, which keeps the sum of the ratings finite
 )
 
 // LaptopServer is the server that provides laptop services
@@ -24,11 +35,21 @@ type LaptopServer struct {
 	LaptopStore LaptopStore
 	ImageStore  ImageStore
 	RatingStore RatingStore
+	// ImageFormats are the names of the formats of the images which can be uploaded
+	ImageFormats []string
+
+	// writes is held for reading while writing to the stores, and for writing while copying them to a snapshot
+	writes sync.RWMutex
 }
 
 // NewLaptopServer creates a new laptop server instance and returns it
 func NewLaptopServer(laptopStore LaptopStore, imageStore ImageStore, ratingStore RatingStore) *LaptopServer {
-	return &LaptopServer{LaptopStore: laptopStore, ImageStore: imageStore, RatingStore: ratingStore}
+	return &LaptopServer{
+		LaptopStore:  laptopStore,
+		ImageStore:   imageStore,
+		RatingStore:  ratingStore,
+		ImageFormats: ImageFormats(),
+	}
 }
 
 // CreateLaptop is a unary RPC to create a new laptop
@@ -40,37 +61,32 @@ func (server *LaptopServer) CreateLaptop(ctx context.Context, req *pb.CreateLapt
 		// check if it's a valid UUID
 		_, err := uuid.Parse(laptop.Id)
 		if err != nil {
-			return nil, status.Errorf(codes.InvalidArgument, "laptop ID is not a valid UUID: %v", err)
+			return nil, invalidArgument("laptop.id", "laptop ID is not a valid UUID: %v", err)
 		}
 	} else {
 		id, err := uuid.NewRandom()
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "cannot generate a new laptop ID: %v", err)
+			return nil, toRPCError(err, resource{}, "cannot generate a new laptop ID")
 		}
 		laptop.Id = id.String()
 	}
 
-	// time.Sleep(6 * time.Second)
-
-	if ctx.Err() == context.Canceled {
-		log.Print("Client cancelled the request")
-		return nil, status.Error(codes.Canceled, "RPC cancelled")
+	if field := nonFiniteField(laptop.ProtoReflect()); field != "" {
+		return nil, invalidArgument("laptop."+field, "laptop %s is not a finite number", field)
 	}
 
-	if ctx.Err() == context.DeadlineExceeded {
-		log.Print("deadline exceeded for some reason")
-		return nil, status.Error(codes.DeadlineExceeded, "deadline exceeded")
+	// time.Sleep(6 * time.Second)
+
+	if err := checkContextError(ctx); err != nil {
+		return nil, err
 	}
 
 	// save the laptop to store
-	err := server.LaptopStore.Save(laptop)
+	err := server.write(func() error {
+		return server.LaptopStore.Save(laptop)
+	})
 	if err != nil {
-		code := codes.Internal
-		if errors.Is(err, ErrAlreadyExists) {
-			code = codes.AlreadyExists
-		}
-
-		return nil, status.Errorf(code, "cannot save laptop to the store: %v", err)
+		return nil, toRPCError(err, laptopResource(laptop.Id), "cannot save laptop to the store")
 	}
 
 	log.Printf("saved laptop with id: %s", laptop.Id)
@@ -81,96 +97,450 @@ func (server *LaptopServer) CreateLaptop(ctx context.Context, req *pb.CreateLapt
 	return res, nil
 }
 
-// SearchLaptop searches for a laptop with a filter and returns a stream of matching laptops
+// GetLaptop is a unary RPC to get a laptop by its ID
+func (server *LaptopServer) GetLaptop(ctx context.Context, req *pb.GetLaptopRequest) (*pb.GetLaptopResponse, error) {
+	laptopID := req.GetId()
+	log.Printf("receive a get-laptop request with id: %s", laptopID)
+
+	laptop, err := server.LaptopStore.Find(laptopID)
+	if err != nil {
+		return nil, toRPCError(err, laptopResource(laptopID), "cannot find laptop")
+	}
+
+	res := &pb.GetLaptopResponse{
+		Laptop: laptop,
+	}
+	return res, nil
+}
+
+// UpdateLaptop is a unary RPC to update an existing laptop, either entirely or only the fields of the update mask
+func (server *LaptopServer) UpdateLaptop(ctx context.Context, req *pb.UpdateLaptopRequest) (*pb.UpdateLaptopResponse, error) {
+	laptop := req.GetLaptop()
+	log.Printf("receive an update-laptop request with id: %s", laptop.GetId())
+
+	if laptop == nil {
+		return nil, invalidArgument("laptop", "laptop is not provided")
+	}
+
+	_, err := uuid.Parse(laptop.Id)
+	if err != nil {
+		return nil, invalidArgument("laptop.id", "laptop ID is not a valid UUID: %v", err)
+	}
+
+	if err := checkContextError(ctx); err != nil {
+		return nil, err
+	}
+
+	expectedRevision := req.GetExpectedRevision()
+
+	mask := req.GetUpdateMask()
+	if len(mask.GetPaths()) > 0 {
+		err := validateLaptopMask(mask)
+		if err != nil {
+			return nil, invalidArgument("update_mask", "%v", err)
+		}
+
+		stored, err := server.LaptopStore.Find(laptop.Id)
+		if err != nil {
+			return nil, toRPCError(err, laptopResource(laptop.Id), "cannot find laptop")
+		}
+		err = checkStoredRevision(laptop.Id, stored.Revision, expectedRevision)
+		if err != nil {
+			return nil, toRPCError(err, laptopResource(laptop.Id), "cannot update laptop")
+		}
+
+		// the stored revision makes the update fail if the laptop changed since it was read
+		applyLaptopMask(stored, laptop, mask)
+		laptop = stored
+	} else {
+		laptop.Revision = expectedRevision
+	}
+
+	if field := nonFiniteField(laptop.ProtoReflect()); field != "" {
+		return nil, invalidArgument("laptop."+field, "laptop %s is not a finite number", field)
+	}
+
+	laptop.UpdatedAt = timestamppb.Now()
+
+	err = server.write(func() error {
+		return server.LaptopStore.Update(laptop)
+	})
+	if err != nil {
+		return nil, toRPCError(err, laptopResource(laptop.Id), "cannot update laptop in the store")
+	}
+
+	log.Printf("updated laptop with id: %s", laptop.Id)
+
+	res := &pb.UpdateLaptopResponse{
+		Laptop: laptop,
+	}
+	return res, nil
+}
+
+// DeleteLaptop is a unary RPC to delete a laptop by its ID
+func (server *LaptopServer) DeleteLaptop(ctx context.Context, req *pb.DeleteLaptopRequest) (*pb.DeleteLaptopResponse, error) {
+	laptopID := req.GetId()
+	log.Printf("receive a delete-laptop request with id: %s", laptopID)
+
+	if err := checkContextError(ctx); err != nil {
+		return nil, err
+	}
+
+	var imageIDs []string
+	err := server.write(func() error {
+		err := server.LaptopStore.Delete(laptopID, req.GetExpectedRevision())
+		if err != nil {
+			return toRPCError(err, laptopResource(laptopID), "cannot delete laptop from the store")
+		}
+
+		imageIDs, err = server.deleteLaptopImages(laptopID)
+		if err != nil {
+			return toRPCError(err, laptopResource(laptopID), "laptop is deleted, but not all of its images")
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, logError(err)
+	}
+
+	log.Printf("deleted laptop with id: %s and its %d images", laptopID, len(imageIDs))
+
+	res := &pb.DeleteLaptopResponse{
+		Id:              laptopID,
+		DeletedImageIds: imageIDs,
+	}
+	return res, nil
+}
+
+// SearchLaptop searches for a laptop with a filter and returns a stream of one page of matching laptops,
+// followed by the token of the next page if there are more
 func (server *LaptopServer) SearchLaptop(req *pb.SearchLaptopRequest, stream pb.LaptopService_SearchLaptopServer) error {
 	filter := req.GetFilter()
 	log.Printf("receive a search-loop request with filter: %v", filter)
 
-	err := server.LaptopStore.Search(stream.Context(), filter, func(laptop *pb.Laptop) error {
+	pageSize := int(req.GetPageSize())
+	switch {
+	case pageSize == 0:
+		pageSize = DEFAULT_PAGE_SIZE
+	case pageSize > MAX_PAGE_SIZE:
+		pageSize = MAX_PAGE_SIZE
+	}
+
+	var predicate query.Predicate
+	if req.GetQuery() != "" {
+		var err error
+		predicate, err = query.Compile(req.GetQuery())
+		if err != nil {
+			return invalidArgument("query", "invalid query: %v", err)
+		}
+	}
+
+	options := SearchOptions{
+		Query:     predicate,
+		Text:      req.GetText(),
+		SortBy:    req.GetSortBy(),
+		Ratings:   server.RatingStore,
+		PageSize:  pageSize,
+		PageToken: req.GetPageToken(),
+	}
+
+	nextPageToken, err := server.LaptopStore.Search(stream.Context(), filter, options, func(laptop *pb.Laptop) error {
 		res := &pb.SearchLaptopResponse{Laptop: laptop}
 		err := stream.Send(res)
 		if err != nil {
-			return err
+			return streamError(err, "cannot send laptop")
 		}
 
 		log.Printf("send laptop with id: %s", laptop.GetId())
 		return nil
 	})
 	if err != nil {
-		return status.Errorf(codes.Internal, "unexpected error: %v", err)
+		return toRPCError(err, resource{}, "cannot search laptops")
+	}
+
+	if nextPageToken != "" {
+		err = stream.Send(&pb.SearchLaptopResponse{NextPageToken: nextPageToken})
+		if err != nil {
+			return streamError(err, "cannot send next page token")
+		}
 	}
 
 	return nil
 }
 
+// FacetLaptops is a unary RPC to count the laptops matching a filter by value of their facets
+func (server *LaptopServer) FacetLaptops(ctx context.Context, req *pb.FacetLaptopsRequest) (*pb.FacetLaptopsResponse, error) {
+	filter := req.GetFilter()
+	log.Printf("receive a facet-laptops request with filter: %v", filter)
+
+	options := FacetOptions{
+		PriceBoundsUsd: req.GetPriceBoundsUsd(),
+		WeightBoundsKg: req.GetWeightBoundsKg(),
+	}
+
+	facets, err := server.LaptopStore.Facets(ctx, filter, options)
+	if err != nil {
+		return nil, toRPCError(err, resource{}, "cannot count laptop facets")
+	}
+
+	res := &pb.FacetLaptopsResponse{
+		Facets: facets,
+	}
+	return res, nil
+}
+
 // UploadImage upload an image using streams to the server, and stores the info in DB
 func (server *LaptopServer) UploadImage(stream pb.LaptopService_UploadImageServer) error {
 	req, err := stream.Recv()
 	if err != nil {
-		return logError(status.Errorf(codes.Unknown, "cannot receive image info"))
+		return logError(streamError(err, "cannot receive image info"))
 	}
 
 	laptopId := req.GetInfo().GetLaptopId()
 	imageType := req.GetInfo().GetImageType()
-	log.Printf("receive an upload-image request for laptop %s with image type %s: ", laptopId, imageType)
+	log.Printf("receive an upload-image request for laptop %s with image type %q", laptopId, imageType)
+
+	// the image type is optional, it is derived from the image data anyway
+	if imageType != "" {
+		format, ok := imageFormatOfType(imageType)
+		if !ok || !slices.Contains(server.ImageFormats, format) {
+			return logError(invalidArgument("info.image_type", "image type %q is not one of the allowed formats %s",
+				imageType, strings.Join(server.ImageFormats, ", ")))
+		}
+	}
 
-	laptop, err := server.LaptopStore.Find(laptopId)
+	_, err = server.LaptopStore.Find(laptopId)
 	if err != nil {
-		return logError(status.Errorf(codes.InvalidArgument, "cannot find laptop %s", laptopId))
+		return logError(toRPCError(err, laptopResource(laptopId), "cannot find laptop"))
 	}
-	if laptop == nil {
-		return logError(status.Errorf(codes.InvalidArgument, "laptop %s doesn't exist", laptopId))
+
+	imageData := &imageChunkReader{stream: stream}
+	reader := bufio.NewReaderSize(imageData, IMAGE_HEADER_SIZE)
+
+	header, err := reader.Peek(IMAGE_HEADER_SIZE)
+	if err != nil && err != io.EOF {
+		return logError(err)
+	}
+
+	imageType, err = server.imageExtension(imageType, bytes.NewReader(header))
+	if err != nil {
+		return logError(err)
 	}
 
-	imageData := bytes.Buffer{}
+	// the image is streamed to the store outside of the writes, so that a slow upload does not hold back
+	// snapshots, it is only listed once it is saved
+	imageID, err := server.ImageStore.Save(laptopId, imageType, reader)
+	if imageData.err != nil && imageData.err != io.EOF {
+		return logError(imageData.err)
+	}
+	if err != nil {
+		return logError(toRPCError(err, laptopResource(laptopId), "cannot save image to the store"))
+	}
+
+	// the laptop may have been deleted, along with its images, while the image was received
+	_, err = server.LaptopStore.Find(laptopId)
+	if err != nil {
+		deleteErr := server.write(func() error {
+			return server.ImageStore.Delete(imageID)
+		})
+		if deleteErr != nil {
+			log.Printf("cannot delete image %s of the missing laptop %s: %v", imageID, laptopId, deleteErr)
+		}
+		return logError(toRPCError(err, laptopResource(laptopId), "cannot find laptop"))
+	}
+
+	res := &pb.UploadImageResponse{
+		Id:   imageID,
+		Size: uint32(imageData.size),
+	}
+
+	err = stream.SendAndClose(res)
+	if err != nil {
+		return logError(streamError(err, "cannot send the response to the client"))
+	}
+
+	log.Printf("image %s successfully stored in our system", imageID)
+
+	return nil
+}
+
+// imageChunkReader reads the image data sent in chunks to an upload-image stream, and fails once
+// the stream is canceled or the data is larger than MAX_ALLOWED_SIZE
+type imageChunkReader struct {
+	stream pb.LaptopService_UploadImageServer
+	chunk  []byte
+	size   int
+	// err is the error which ended the stream, io.EOF once it is received entirely
+	err error
+}
+
+func (reader *imageChunkReader) Read(p []byte) (int, error) {
+	for len(reader.chunk) == 0 {
+		if reader.err != nil {
+			return 0, reader.err
+		}
+		reader.err = reader.receive()
+	}
+
+	n := copy(p, reader.chunk)
+	reader.chunk = reader.chunk[n:]
+	return n, nil
+}
+
+// receive receives the next chunk of the stream
+func (reader *imageChunkReader) receive() error {
+	if err := checkContextError(reader.stream.Context()); err != nil {
+		return err
+	}
+
+	req, err := reader.stream.Recv()
+	if err == io.EOF {
+		log.Print("no more data")
+		return io.EOF
+	}
+	if err != nil {
+		return streamError(err, "cannot receive chunk data")
+	}
+
+	chunk := req.GetChunkData()
+	log.Printf("received a chunk with size: %d", len(chunk))
+
+	reader.size += len(chunk)
+	if reader.size > MAX_ALLOWED_SIZE {
+		return invalidArgument("chunk_data", "image size is bigger then the allowed_size=%d", MAX_ALLOWED_SIZE)
+	}
+
+	reader.chunk = chunk
+	return nil
+}
+
+// imageExtension returns the extension to save the image data with, once its format is sniffed
+// and checked to be allowed and to match the image type given by the client, if any
+func (server *LaptopServer) imageExtension(imageType string, data io.Reader) (string, error) {
+	format, err := sniffImageFormat(data)
+	if err != nil {
+		return "", invalidArgument("chunk_data", "cannot detect the image format: %v", err)
+	}
+	if !slices.Contains(server.ImageFormats, format) {
+		return "", invalidArgument("chunk_data", "image format %s is not one of the allowed formats %s",
+			format, strings.Join(server.ImageFormats, ", "))
+	}
+
+	if imageType != "" {
+		typeFormat, _ := imageFormatOfType(imageType)
+		if typeFormat != format {
+			return "", invalidArgument("info.image_type", "image type %q does not match the %s image data", imageType, format)
+		}
+	}
+
+	return imageExtensions[format][0], nil
+}
+
+// DownloadImage is a server-streaming RPC that returns the info of an image, followed by its data in chunks
+func (server *LaptopServer) DownloadImage(req *pb.DownloadImageRequest, stream pb.LaptopService_DownloadImageServer) error {
+	imageID := req.GetImageId()
+	log.Printf("receive a download-image request for image %s", imageID)
+
+	info, reader, err := server.ImageStore.Open(imageID)
+	if err != nil {
+		return logError(toRPCError(err, imageResource(imageID), "cannot open image"))
+	}
+	defer reader.Close()
+
+	res := &pb.DownloadImageResponse{
+		Data: &pb.DownloadImageResponse_Info{
+			Info: &pb.ImageInfo{
+				LaptopId:  info.LaptopID,
+				ImageType: info.Type,
+			},
+		},
+	}
+	err = stream.Send(res)
+	if err != nil {
+		return logError(streamError(err, "cannot send image info"))
+	}
+
+	buffer := make([]byte, CHUNK_SIZE)
 	imageSize := 0
 
 	for {
 		if err := checkContextError(stream.Context()); err != nil {
 			return err
 		}
-		log.Print("waiting to receive more data")
 
-		req, err := stream.Recv()
+		n, err := reader.Read(buffer)
+		if n > 0 {
+			res := &pb.DownloadImageResponse{
+				Data: &pb.DownloadImageResponse_ChunkData{
+					ChunkData: buffer[:n],
+				},
+			}
+
+			err := stream.Send(res)
+			if err != nil {
+				return logError(streamError(err, "cannot send chunk data"))
+			}
+			imageSize += n
+		}
 		if err == io.EOF {
-			log.Print("no more data")
 			break
 		}
+		if err != nil {
+			return logError(toRPCError(err, imageResource(imageID), "cannot read image data"))
+		}
+	}
 
-		chunk := req.GetChunkData()
-		size := len(chunk)
-
-		log.Printf("received a chunk with size: %d", size)
+	log.Printf("sent image %s with size: %d", imageID, imageSize)
+	return nil
+}
 
-		imageSize += size
-		if imageSize > MAX_ALLOWED_SIZE {
-			return logError(status.Errorf(codes.InvalidArgument, "image size is bigger then the allowed_size=%d", MAX_ALLOWED_SIZE))
-		}
+// ListLaptopImages is a unary RPC to list the images of a laptop
+func (server *LaptopServer) ListLaptopImages(ctx context.Context, req *pb.ListLaptopImagesRequest) (*pb.ListLaptopImagesResponse, error) {
+	laptopID := req.GetLaptopId()
+	log.Printf("receive a list-laptop-images request for laptop %s", laptopID)
 
-		_, err = imageData.Write(chunk)
-		if err != nil {
-			return logError(status.Errorf(codes.Internal, "cannot write chunk data: %v", err))
-		}
+	_, err := server.LaptopStore.Find(laptopID)
+	if err != nil {
+		return nil, toRPCError(err, laptopResource(laptopID), "cannot find laptop")
 	}
 
-	imageID, err := server.ImageStore.Save(laptopId, imageType, imageData)
+	images, err := server.ImageStore.ListByLaptop(laptopID)
 	if err != nil {
-		return logError(status.Errorf(codes.Internal, "cannot save to the store %s", imageID))
+		return nil, toRPCError(err, laptopResource(laptopID), "cannot list laptop images")
 	}
 
-	res := &pb.UploadImageResponse{
-		Id:   imageID,
-		Size: uint32(imageSize),
+	res := &pb.ListLaptopImagesResponse{}
+	for _, imageID := range sortedKeys(images) {
+		res.Images = append(res.Images, &pb.LaptopImage{
+			Id:        imageID,
+			ImageType: images[imageID].Type,
+		})
 	}
+	return res, nil
+}
 
-	err = stream.SendAndClose(res)
+// DeleteImage is a unary RPC to delete an image by its ID
+func (server *LaptopServer) DeleteImage(ctx context.Context, req *pb.DeleteImageRequest) (*pb.DeleteImageResponse, error) {
+	imageID := req.GetImageId()
+	log.Printf("receive a delete-image request with id: %s", imageID)
+
+	if err := checkContextError(ctx); err != nil {
+		return nil, err
+	}
+
+	err := server.write(func() error {
+		return server.ImageStore.Delete(imageID)
+	})
 	if err != nil {
-		return logError(status.Errorf(codes.Unknown, "cannot send the response to the client %v", err))
+		return nil, toRPCError(err, imageResource(imageID), "cannot delete image from the store")
 	}
 
-	log.Printf("image %s successfully stored in our system", imageID)
+	log.Printf("deleted image with id: %s", imageID)
 
-	return nil
+	res := &pb.DeleteImageResponse{
+		ImageId: imageID,
+	}
+	return res, nil
 }
 
 // RateLaptop is a bidirectional-streaming RPC that allows client to create a stream of laptops
@@ -187,7 +557,7 @@ func (s *LaptopServer) RateLaptop(stream pb.LaptopService_RateLaptopServer) erro
 			break
 		}
 		if err != nil {
-			return logError(status.Errorf(codes.Unknown, "cannot receive stream request: %v", err))
+			return logError(streamError(err, "cannot receive stream request"))
 		}
 
 		laptopID := req.GetLaptopId()
@@ -195,17 +565,22 @@ func (s *LaptopServer) RateLaptop(stream pb.LaptopService_RateLaptopServer) erro
 
 		log.Printf("received a rate-laptop request: id = %s, score = %.2f", laptopID, score)
 
-		found, err := s.LaptopStore.Find(laptopID)
-		if err != nil {
-			return logError(status.Errorf(codes.Internal, "cannot find laptop: %v", err))
+		if !(score >= 0 && score <= MAX_SCORE) {
+			return logError(invalidArgument("score", "score %v is not between 0 and %d", score, MAX_SCORE))
 		}
-		if found == nil {
-			return logError(status.Errorf(codes.NotFound, "laptop %s is not found", laptopID))
+
+		_, err = s.LaptopStore.Find(laptopID)
+		if err != nil {
+			return logError(toRPCError(err, laptopResource(laptopID), "cannot find laptop"))
 		}
 
-		rating, err := s.RatingStore.Add(laptopID, score)
+		var rating *Rating
+		err = s.write(func() error {
+			rating, err = s.RatingStore.Add(laptopID, score)
+			return err
+		})
 		if err != nil {
-			return logError(status.Errorf(codes.Internal, "cannot create laptop rating: %v", err))
+			return logError(toRPCError(err, laptopResource(laptopID), "cannot create laptop rating"))
 		}
 
 		res := &pb.RateLaptopResponse{
@@ -216,19 +591,58 @@ func (s *LaptopServer) RateLaptop(stream pb.LaptopService_RateLaptopServer) erro
 
 		err = stream.Send(res)
 		if err != nil {
-			return logError(status.Errorf(codes.Unknown, "cannot send stream response: %v", err))
+			return logError(streamError(err, "cannot send stream response"))
 		}
 	}
 
 	return nil
 }
 
+// deleteLaptopImages deletes the images of a deleted laptop and returns their IDs
+func (server *LaptopServer) deleteLaptopImages(laptopID string) ([]string, error) {
+	if server.ImageStore == nil {
+		return nil, nil
+	}
+
+	images, err := server.ImageStore.ListByLaptop(laptopID)
+	if err != nil {
+		return nil, err
+	}
+
+	var imageIDs []string
+	for _, imageID := range sortedKeys(images) {
+		err := server.ImageStore.Delete(imageID)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return imageIDs, err
+		}
+		imageIDs = append(imageIDs, imageID)
+	}
+
+	return imageIDs, nil
+}
+
+// write runs the write to the stores, waiting for the snapshot copying them if any
+func (server *LaptopServer) write(write func() error) error {
+	server.writes.RLock()
+	defer server.writes.RUnlock()
+
+	return write()
+}
+
+// snapshot copies the stores while blocking their writes, for the snapshot to be consistent
+func (server *LaptopServer) snapshot(ctx context.Context, userStore UserStore) (*Snapshot, error) {
+	server.writes.Lock()
+	defer server.writes.Unlock()
+
+	return TakeSnapshot(ctx, server.LaptopStore, server.RatingStore, userStore, server.ImageStore)
+}
+
 func checkContextError(ctx context.Context) error {
 	switch ctx.Err() {
 	case context.Canceled:
-		return logError(status.Errorf(codes.Canceled, "request is cancelled, server cancelling"))
+		return logError(toRPCError(ctx.Err(), resource{}, "request is cancelled, server cancelling"))
 	case context.DeadlineExceeded:
-		return logError(status.Errorf(codes.Canceled, "deadline exceeded, server cancelling"))
+		return logError(toRPCError(ctx.Err(), resource{}, "deadline exceeded, server cancelling"))
 	default:
 		return nil
 	}
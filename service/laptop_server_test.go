@@ -2,6 +2,10 @@ package service_test
 
 import (
 	"context"
+	"math"
+	"os"
+	"sort"
+	"strings"
 	"testing"
 
 	"otmane/pcbook/pb"
@@ -11,6 +15,8 @@ import (
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 func TestServerCreateLaptop(t *testing.T) {
@@ -22,6 +28,12 @@ func TestServerCreateLaptop(t *testing.T) {
 	laptopInvalidID := sample.NewLaptop()
 	laptopInvalidID.Id = "invalid-uuid"
 
+	laptopNaNPrice := sample.NewLaptop()
+	laptopNaNPrice.PriceUsd = math.NaN()
+
+	laptopInfiniteWeight := sample.NewLaptop()
+	laptopInfiniteWeight.Weight = &pb.Laptop_WeightKg{WeightKg: math.Inf(1)}
+
 	laptopDuplicateID := sample.NewLaptop()
 	storeDuplicateID := service.NewInMemoryLaptopStore()
 	err := storeDuplicateID.Save(laptopDuplicateID)
@@ -51,6 +63,18 @@ func TestServerCreateLaptop(t *testing.T) {
 			store:  service.NewInMemoryLaptopStore(),
 			code:   codes.InvalidArgument,
 		},
+		{
+			name:   "failure_nan_price",
+			laptop: laptopNaNPrice,
+			store:  service.NewInMemoryLaptopStore(),
+			code:   codes.InvalidArgument,
+		},
+		{
+			name:   "failure_infinite_weight",
+			laptop: laptopInfiniteWeight,
+			store:  service.NewInMemoryLaptopStore(),
+			code:   codes.InvalidArgument,
+		},
 		{
 			name:   "failure_duplicate_id",
 			laptop: laptopDuplicateID,
@@ -88,3 +112,281 @@ func TestServerCreateLaptop(t *testing.T) {
 		})
 	}
 }
+
+func TestServerGetLaptop(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	store := service.NewInMemoryLaptopStore()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	server := service.NewLaptopServer(store, nil, nil)
+
+	res, err := server.GetLaptop(context.Background(), &pb.GetLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+	requireSameLaptop(t, laptop, res.GetLaptop())
+
+	res, err = server.GetLaptop(context.Background(), &pb.GetLaptopRequest{Id: sample.NewLaptop().Id})
+	require.Nil(t, res)
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServerUpdateLaptop(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	store := service.NewInMemoryLaptopStore()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	laptopInvalidID := sample.NewLaptop()
+	laptopInvalidID.Id = "invalid-uuid"
+
+	testCases := []struct {
+		name   string
+		laptop *pb.Laptop
+		code   codes.Code
+	}{
+		{
+			name:   "success",
+			laptop: laptop,
+			code:   codes.OK,
+		},
+		{
+			name:   "failure_not_found",
+			laptop: sample.NewLaptop(),
+			code:   codes.NotFound,
+		},
+		{
+			name:   "failure_invalid_id",
+			laptop: laptopInvalidID,
+			code:   codes.InvalidArgument,
+		},
+		{
+			name:   "failure_no_laptop",
+			laptop: nil,
+			code:   codes.InvalidArgument,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			updated := proto.Clone(tc.laptop).(*pb.Laptop)
+			if updated != nil {
+				updated.PriceUsd = 999
+			}
+
+			server := service.NewLaptopServer(store, nil, nil)
+			res, err := server.UpdateLaptop(context.Background(), &pb.UpdateLaptopRequest{Laptop: updated})
+			if tc.code != codes.OK {
+				require.Nil(t, res)
+				require.Equal(t, tc.code, status.Code(err))
+				return
+			}
+
+			require.NoError(t, err)
+			require.False(t, res.GetLaptop().GetUpdatedAt().AsTime().Before(laptop.GetUpdatedAt().AsTime()))
+
+			other, err := store.Find(laptop.Id)
+			require.NoError(t, err)
+			require.Equal(t, 999.0, other.GetPriceUsd())
+		})
+	}
+}
+
+func TestServerDeleteLaptop(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	store := service.NewInMemoryLaptopStore()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	server := service.NewLaptopServer(store, nil, nil)
+
+	res, err := server.DeleteLaptop(context.Background(), &pb.DeleteLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+	require.Equal(t, laptop.Id, res.GetId())
+
+	_, err = store.Find(laptop.Id)
+	require.ErrorIs(t, err, service.ErrNotFound)
+
+	res, err = server.DeleteLaptop(context.Background(), &pb.DeleteLaptopRequest{Id: laptop.Id})
+	require.Nil(t, res)
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServerLaptopImages(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	other := sample.NewLaptop()
+	laptopStore := service.NewInMemoryLaptopStore()
+	require.NoError(t, laptopStore.Save(laptop))
+	require.NoError(t, laptopStore.Save(other))
+
+	imageFolder := t.TempDir()
+	imageStore := service.NewDiskImageStore(imageFolder)
+	server := service.NewLaptopServer(laptopStore, imageStore, nil)
+
+	var imageIDs []string
+	for _, imageType := range []string{".jpg", ".png", ".jpg"} {
+		imageID, err := imageStore.Save(laptop.Id, imageType, strings.NewReader("image"))
+		require.NoError(t, err)
+		imageIDs = append(imageIDs, imageID)
+	}
+	otherImageID, err := imageStore.Save(other.Id, ".jpg", strings.NewReader("image"))
+	require.NoError(t, err)
+	sort.Strings(imageIDs)
+
+	listImageIDs := func(laptopID string) []string {
+		res, err := server.ListLaptopImages(context.Background(), &pb.ListLaptopImagesRequest{LaptopId: laptopID})
+		require.NoError(t, err)
+
+		var ids []string
+		for _, image := range res.GetImages() {
+			ids = append(ids, image.GetId())
+		}
+		return ids
+	}
+	require.Equal(t, imageIDs, listImageIDs(laptop.Id))
+	require.Equal(t, []string{otherImageID}, listImageIDs(other.Id))
+
+	_, err = server.ListLaptopImages(context.Background(), &pb.ListLaptopImagesRequest{LaptopId: sample.NewLaptop().Id})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// deleting an image removes its file
+	res, err := server.DeleteImage(context.Background(), &pb.DeleteImageRequest{ImageId: imageIDs[0]})
+	require.NoError(t, err)
+	require.Equal(t, imageIDs[0], res.GetImageId())
+	require.Equal(t, imageIDs[1:], listImageIDs(laptop.Id))
+
+	_, err = server.DeleteImage(context.Background(), &pb.DeleteImageRequest{ImageId: imageIDs[0]})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// deleting a laptop deletes its images, and only them
+	deleted, err := server.DeleteLaptop(context.Background(), &pb.DeleteLaptopRequest{Id: laptop.Id})
+	require.NoError(t, err)
+	require.Equal(t, imageIDs[1:], deleted.GetDeletedImageIds())
+
+	images, err := imageStore.List()
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	require.Contains(t, images, otherImageID)
+
+	files, err := os.ReadDir(imageFolder)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, otherImageID+".jpg", files[0].Name())
+}
+
+func TestServerUpdateLaptopWithMask(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	store := service.NewInMemoryLaptopStore()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	server := service.NewLaptopServer(store, nil, nil)
+
+	changes := &pb.Laptop{
+		Id:       laptop.Id,
+		Brand:    "ignored",
+		PriceUsd: 1234,
+		Cpu:      &pb.CPU{MaxGhz: 4.2},
+		Weight:   &pb.Laptop_WeightLb{WeightLb: 4.5},
+	}
+	req := &pb.UpdateLaptopRequest{
+		Laptop:     changes,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"price_usd", "cpu.max_ghz", "weight"}},
+	}
+
+	res, err := server.UpdateLaptop(context.Background(), req)
+	require.NoError(t, err)
+
+	updated := res.GetLaptop()
+	require.Equal(t, laptop.GetBrand(), updated.GetBrand())
+	require.Equal(t, 1234.0, updated.GetPriceUsd())
+	require.Equal(t, 4.2, updated.GetCpu().GetMaxGhz())
+	require.Equal(t, laptop.GetCpu().GetName(), updated.GetCpu().GetName())
+	require.Equal(t, 4.5, updated.GetWeightLb())
+	require.Zero(t, updated.GetWeightKg())
+
+	req.UpdateMask.Paths = []string{"price_usd", "cpu.speed", "gpus.name", "id"}
+	res, err = server.UpdateLaptop(context.Background(), req)
+	require.Nil(t, res)
+
+	st, ok := status.FromError(err)
+	require.True(t, ok)
+	require.Equal(t, codes.InvalidArgument, st.Code())
+	require.Contains(t, st.Message(), "cpu.speed, gpus.name, id")
+
+	// the values which cannot be sorted nor encoded in page tokens are rejected
+	req.Laptop.Cpu.MaxGhz = math.NaN()
+	req.UpdateMask.Paths = []string{"cpu.max_ghz"}
+	_, err = server.UpdateLaptop(context.Background(), req)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), "cpu.max_ghz")
+
+	req.Laptop.Weight = &pb.Laptop_WeightLb{WeightLb: math.Inf(-1)}
+	req.UpdateMask.Paths = []string{"weight"}
+	_, err = server.UpdateLaptop(context.Background(), req)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	require.Contains(t, status.Convert(err).Message(), "weight_lb")
+
+	stored, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Equal(t, 4.2, stored.GetCpu().GetMaxGhz())
+	require.Equal(t, 4.5, stored.GetWeightLb())
+
+	// the revision is set by the store, a client cannot bypass the revision check with it
+	for _, paths := range [][]string{{"price_usd", "revision"}, {"revision"}} {
+		req.Laptop.Revision = 0
+		req.UpdateMask.Paths = paths
+		_, err = server.UpdateLaptop(context.Background(), req)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Contains(t, status.Convert(err).Message(), "revision")
+	}
+}
+
+func TestServerLaptopRevision(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	store := service.NewInMemoryLaptopStore()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, laptop.Revision)
+
+	server := service.NewLaptopServer(store, nil, nil)
+
+	req := &pb.UpdateLaptopRequest{
+		Laptop:           &pb.Laptop{Id: laptop.Id, PriceUsd: 1000},
+		UpdateMask:       &fieldmaskpb.FieldMask{Paths: []string{"price_usd"}},
+		ExpectedRevision: 1,
+	}
+	res, err := server.UpdateLaptop(context.Background(), req)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, res.GetLaptop().GetRevision())
+
+	// a second writer still holding the first revision must re-read the laptop
+	req.Laptop.PriceUsd = 2000
+	res, err = server.UpdateLaptop(context.Background(), req)
+	require.Nil(t, res)
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	stale := proto.Clone(laptop).(*pb.Laptop)
+	res, err = server.UpdateLaptop(context.Background(), &pb.UpdateLaptopRequest{Laptop: stale, ExpectedRevision: 1})
+	require.Nil(t, res)
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	_, err = server.DeleteLaptop(context.Background(), &pb.DeleteLaptopRequest{Id: laptop.Id, ExpectedRevision: 1})
+	require.Equal(t, codes.Aborted, status.Code(err))
+
+	_, err = server.DeleteLaptop(context.Background(), &pb.DeleteLaptopRequest{Id: laptop.Id, ExpectedRevision: 2})
+	require.NoError(t, err)
+}
@@ -9,33 +9,53 @@ import (
 
 	"otmane/pcbook/pb"
 
-	"github.com/jinzhu/copier"
+	"google.golang.org/protobuf/proto"
 )
 
 // ErrAlreadyExists is returned when a record with the same ID already exists in the store
 var ErrAlreadyExists = errors.New("record already exists")
+
+// ErrNotFound is returned when no record with the given ID exists in the store
 var ErrNotFound = errors.New("record not found")
 
+// ErrConflict is returned when a write conflicts with the current state of the record,
+// it may succeed once retried on the new state
+var ErrConflict = errors.New("record conflict")
+
+// ErrRevisionMismatch is returned when a write expects another revision than the stored one
+var ErrRevisionMismatch = fmt.Errorf("%w: revision mismatch", ErrConflict)
+
 // LaptopStore is an interface to store laptop
 type LaptopStore interface {
-	// Save saves the laptop to the store
+	// Save saves the laptop to the store and sets its revision to the first one
 	Save(laptop *pb.Laptop) error
 	// Find searches for a laptop by its ID
 	Find(id string) (*pb.Laptop, error)
-	// Search searches for laptops with filter, return one by one via found function
-	Search(ctx context.Context, filter *pb.Filter, found func(laptop *pb.Laptop) error) error
+	// Update replaces an existing laptop in the store and sets its revision to the next one.
+	// The revision of the given laptop must match the stored one, unless it is 0.
+	Update(laptop *pb.Laptop) error
+	// Delete removes a laptop from the store by its ID.
+	// The revision must match the stored one, unless it is 0.
+	Delete(id string, revision uint64) error
+	// Search searches for laptops with filter, return one by one via found function in the order of the options.
+	// It returns the token of the next page when more laptops match than the page size of the options.
+	Search(ctx context.Context, filter *pb.Filter, options SearchOptions, found func(laptop *pb.Laptop) error) (string, error)
+	// Facets counts the laptops matching the filter by value of their brand, CPU, GPU, RAM, storage,
+	// screen, keyboard, price and weight
+	Facets(ctx context.Context, filter *pb.Filter, options FacetOptions) (*pb.Facets, error)
 }
 
-// InMemoryLaptopStore stores laptop in memory
+// InMemoryLaptopStore stores laptop in memory. Searches read a snapshot of the store,
+// so that streaming their results does not block the writers.
 type InMemoryLaptopStore struct {
 	mutex sync.RWMutex
-	data  map[string]*pb.Laptop
+	table *laptopTable
 }
 
 // NewInMemoryLaptopStore returns a new InMemoryLaptopStore
 func NewInMemoryLaptopStore() *InMemoryLaptopStore {
 	return &InMemoryLaptopStore{
-		data: make(map[string]*pb.Laptop),
+		table: newLaptopTable(),
 	}
 }
 
@@ -44,74 +64,220 @@ func (store *InMemoryLaptopStore) Save(laptop *pb.Laptop) error {
 	store.mutex.Lock()
 	defer store.mutex.Unlock()
 
-	if store.data[laptop.Id] != nil {
-		return ErrAlreadyExists
+	if store.table.get(laptop.Id) != nil {
+		return fmt.Errorf("laptop %s: %w", laptop.Id, ErrAlreadyExists)
 	}
 
-	other, err := deepCopy(laptop)
-	if err != nil {
-		return err
-	}
+	other := deepCopy(laptop)
+	other.Revision = 1
+	store.table.put(other, nil)
 
-	store.data[other.Id] = other
+	laptop.Revision = other.Revision
 	return nil
 }
 
+// Find searches for a laptop by its ID
 func (store *InMemoryLaptopStore) Find(id string) (*pb.Laptop, error) {
 	store.mutex.RLock()
 	defer store.mutex.RUnlock()
 
-	laptop, ok := store.data[id]
-	if !ok {
-		return nil, fmt.Errorf("cannot")
+	laptop := store.table.get(id)
+	if laptop == nil {
+		return nil, fmt.Errorf("laptop %s: %w", id, ErrNotFound)
+	}
+
+	return deepCopy(laptop), nil
+}
+
+// Update replaces an existing laptop in the store and sets its revision to the next one
+func (store *InMemoryLaptopStore) Update(laptop *pb.Laptop) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	stored := store.table.get(laptop.Id)
+	err := checkRevision(laptop.Id, stored, laptop.Revision)
+	if err != nil {
+		return err
+	}
+
+	other := deepCopy(laptop)
+	other.Revision = stored.Revision + 1
+	store.table.put(other, stored)
+
+	laptop.Revision = other.Revision
+	return nil
+}
+
+// Delete removes a laptop from the store by its ID
+func (store *InMemoryLaptopStore) Delete(id string, revision uint64) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	stored := store.table.get(id)
+	err := checkRevision(id, stored, revision)
+	if err != nil {
+		return err
 	}
 
-	return deepCopy(laptop)
+	store.table.delete(stored)
+	return nil
 }
 
-func (store *InMemoryLaptopStore) Search(ctx context.Context, filter *pb.Filter, found func(laptop *pb.Laptop) error) error {
+// stored returns the stored laptop with the ID, which must not be modified, or nil if there is none
+func (store *InMemoryLaptopStore) stored(id string) *pb.Laptop {
 	store.mutex.RLock()
 	defer store.mutex.RUnlock()
 
-	for _, laptop := range store.data {
+	return store.table.get(id)
+}
+
+// len returns the number of laptops in the store
+func (store *InMemoryLaptopStore) len() int {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	return store.table.laptops.Len()
+}
+
+// put stores the laptop as it is, with its revision, replacing the one with the same ID if any.
+// The store takes ownership of the laptop.
+func (store *InMemoryLaptopStore) put(laptop *pb.Laptop) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.table.put(laptop, store.table.get(laptop.Id))
+}
+
+// remove removes the laptop with the ID from the store if it exists
+func (store *InMemoryLaptopStore) remove(id string) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	if stored := store.table.get(id); stored != nil {
+		store.table.delete(stored)
+	}
+}
+
+// Search searches for laptops with filter, return one by one via found function in the order of the options
+func (store *InMemoryLaptopStore) Search(
+	ctx context.Context,
+	filter *pb.Filter,
+	options SearchOptions,
+	found func(laptop *pb.Laptop) error,
+) (string, error) {
+	cursor, err := options.validate()
+	if err != nil {
+		return "", err
+	}
+
+	err = validateFilter(filter)
+	if err != nil {
+		return "", err
+	}
+
+	hits, err := store.snapshot().searchHits(ctx, filter, options, cursor, true)
+	if err != nil {
+		return "", err
+	}
+
+	sortHits(hits, options)
+	return sendPage(hits, options, found)
+}
+
+// searchHits returns the hits of the laptops matching the search after the cursor,
+// looking them up with the query planner unless planned is false
+func (table *laptopTable) searchHits(
+	ctx context.Context,
+	filter *pb.Filter,
+	options SearchOptions,
+	cursor *pageCursor,
+	planned bool,
+) ([]searchHit, error) {
+	var relevance map[string]float64
+	if options.Text != "" {
+		relevance = table.text.search(options.Text)
+	}
+
+	var hits []searchHit
+	err := table.forEachCandidate(filter, relevance, planned, func(laptop *pb.Laptop) error {
 		if ctx.Err() == context.DeadlineExceeded || ctx.Err() == context.Canceled {
 			log.Println("context deadline exceeded")
-			return errors.New("deadline exceeded, dropping the search")
+			return fmt.Errorf("dropping the search: %w", ctx.Err())
 		}
-		if isQualified(filter, laptop) {
-			other, err := deepCopy(laptop)
-			if err != nil {
-				return err
-			}
-
-			err = found(other)
-			if err != nil {
-				return err
-			}
+		if relevance != nil && relevance[laptop.GetId()] == 0 {
+			return nil
+		}
+		if !options.matches(filter, laptop) {
+			return nil
 		}
+
+		hit := newSearchHit(laptop, relevance[laptop.GetId()], options)
+		if hit.isAfter(cursor, options) {
+			hits = append(hits, hit)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return hits, nil
 }
 
-func isQualified(filter *pb.Filter, laptop *pb.Laptop) bool {
-	if laptop.GetPriceUsd() > filter.GetMaxPriceUsd() {
-		return false
+// Facets counts the laptops matching the filter by value of their facets
+func (store *InMemoryLaptopStore) Facets(ctx context.Context, filter *pb.Filter, options FacetOptions) (*pb.Facets, error) {
+	err := options.validate()
+	if err != nil {
+		return nil, err
 	}
 
-	if laptop.GetCpu().GetNumberCores() < filter.GetMinCpuCors() {
-		return false
+	err = validateFilter(filter)
+	if err != nil {
+		return nil, err
 	}
 
-	if laptop.GetCpu().GetMinGhz() < filter.GetMinCpuGhz() {
-		return false
+	counter := newFacetCounter(options)
+	err = store.snapshot().forEachCandidate(filter, nil, true, func(laptop *pb.Laptop) error {
+		if ctx.Err() == context.DeadlineExceeded || ctx.Err() == context.Canceled {
+			log.Println("context deadline exceeded")
+			return fmt.Errorf("dropping the facets: %w", ctx.Err())
+		}
+		if isQualified(filter, laptop) {
+			counter.add(laptop)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if toBit(laptop.GetRam()) < toBit(filter.GetMinRam()) {
-		return false
+	return counter.facets(), nil
+}
+
+// snapshot returns a snapshot of the laptops of the store, to read without holding the lock
+func (store *InMemoryLaptopStore) snapshot() *laptopTable {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	return store.table.snapshot()
+}
+
+// checkRevision returns an error wrapping ErrNotFound if the stored laptop is nil,
+// or ErrRevisionMismatch if the expected revision is not 0 nor the stored one
+func checkRevision(id string, stored *pb.Laptop, revision uint64) error {
+	if stored == nil {
+		return fmt.Errorf("laptop %s: %w", id, ErrNotFound)
+	}
+
+	return checkStoredRevision(id, stored.Revision, revision)
+}
+
+func checkStoredRevision(id string, stored uint64, revision uint64) error {
+	if revision != 0 && revision != stored {
+		return fmt.Errorf("laptop %s has revision %d, expected %d: %w", id, stored, revision, ErrRevisionMismatch)
 	}
 
-	return true
+	return nil
 }
 
 func toBit(memory *pb.Memory) uint64 {
@@ -135,12 +301,8 @@ func toBit(memory *pb.Memory) uint64 {
 	}
 }
 
-func deepCopy(from *pb.Laptop) (*pb.Laptop, error) {
-	to := &pb.Laptop{}
-	err := copier.Copy(to, from)
-	if err != nil {
-		return nil, fmt.Errorf("cannot copy laptop data: %w", err)
-	}
-
-	return to, nil
+// deepCopy returns a copy of the laptop sharing nothing with it, including its unknown fields.
+// The stored laptops are never modified, so they are copied only when given or returned to callers.
+func deepCopy(from *pb.Laptop) *pb.Laptop {
+	return proto.Clone(from).(*pb.Laptop)
 }
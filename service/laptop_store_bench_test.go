@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"otmane/pcbook/pb"
+	"otmane/pcbook/sample"
+
+	"github.com/jinzhu/copier"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// copierDeepCopy is the reflection based copy the store used before proto.Clone
+func copierDeepCopy(from *pb.Laptop) *pb.Laptop {
+	to := &pb.Laptop{}
+	err := copier.Copy(to, from)
+	if err != nil {
+		panic(err)
+	}
+
+	return to
+}
+
+// benchmarkCopies runs the benchmark with the old and the new deep copy of the store
+func benchmarkCopies(b *testing.B, name string, run func(b *testing.B, clone func(from *pb.Laptop) *pb.Laptop)) {
+	copies := []struct {
+		name  string
+		clone func(from *pb.Laptop) *pb.Laptop
+	}{
+		{"copier", copierDeepCopy},
+		{"proto", deepCopy},
+	}
+
+	for _, c := range copies {
+		b.Run(fmt.Sprintf("%s/%s", name, c.name), func(b *testing.B) {
+			run(b, c.clone)
+		})
+	}
+}
+
+// BenchmarkInMemoryLaptopStore runs the operations of the store the way its methods do, copying
+// the laptops given to or returned by them with each deep copy
+func BenchmarkInMemoryLaptopStore(b *testing.B) {
+	for _, n := range []int{10000, 100000} {
+		store := newFilledLaptopStore(b, n)
+
+		var ids []string
+		store.table.laptops.Ascend(func(laptop *pb.Laptop) bool {
+			ids = append(ids, laptop.GetId())
+			return true
+		})
+
+		benchmarkCopies(b, fmt.Sprintf("Save/%d", n), func(b *testing.B, clone func(from *pb.Laptop) *pb.Laptop) {
+			laptops := make([]*pb.Laptop, b.N)
+			for i := range laptops {
+				laptops[i] = sample.NewLaptop()
+				laptops[i].Revision = 1
+			}
+
+			b.ResetTimer()
+			for _, laptop := range laptops {
+				store.put(clone(laptop))
+			}
+
+			b.StopTimer()
+			for _, laptop := range laptops {
+				store.remove(laptop.GetId())
+			}
+		})
+
+		benchmarkCopies(b, fmt.Sprintf("Find/%d", n), func(b *testing.B, clone func(from *pb.Laptop) *pb.Laptop) {
+			for i := 0; i < b.N; i++ {
+				laptop := store.stored(ids[i%len(ids)])
+				if laptop == nil {
+					b.Fatal("laptop not found")
+				}
+				clone(laptop)
+			}
+		})
+
+		benchmarkCopies(b, fmt.Sprintf("Search/%d", n), func(b *testing.B, clone func(from *pb.Laptop) *pb.Laptop) {
+			filter := &pb.Filter{MinPriceUsd: proto.Float64(2000), MaxPriceUsd: proto.Float64(2100)}
+			for i := 0; i < b.N; i++ {
+				hits, err := store.snapshot().searchHits(context.Background(), filter, SearchOptions{}, nil, true)
+				if err != nil {
+					b.Fatal(err)
+				}
+
+				sortHits(hits, SearchOptions{})
+				for _, hit := range hits {
+					clone(hit.laptop)
+				}
+			}
+		})
+	}
+}
+
+func TestDeepCopy(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	laptop.Weight = &pb.Laptop_WeightLb{WeightLb: 4.4}
+	laptop.ProtoReflect().SetUnknown([]byte{0xf8, 0x07, 0x01}) // field 127, varint 1
+
+	other := deepCopy(laptop)
+	require.True(t, proto.Equal(laptop, other))
+
+	other.Cpu.Name = "changed"
+	other.Gpus[0].Memory.Value++
+	other.Weight.(*pb.Laptop_WeightLb).WeightLb++
+	require.NotEqual(t, "changed", laptop.Cpu.Name)
+	require.NotEqual(t, laptop.Gpus[0].Memory.Value, other.Gpus[0].Memory.Value)
+	require.Equal(t, 4.4, laptop.GetWeightLb())
+}
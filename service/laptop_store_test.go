@@ -0,0 +1,276 @@
+package service_test
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"otmane/pcbook/pb"
+	"otmane/pcbook/sample"
+	"otmane/pcbook/service"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestInMemoryLaptopStoreSearchFilter(t *testing.T) {
+	t.Parallel()
+
+	laptop := sample.NewLaptop()
+	laptop.Brand = "Dell"
+	laptop.PriceUsd = 1800
+	laptop.Gpus = []*pb.GPU{
+		{Brand: "Nvidia", Memory: &pb.Memory{Value: 4, Unit: pb.Memory_GIGABYTE}},
+		{Brand: "AMD", Memory: &pb.Memory{Value: 8, Unit: pb.Memory_GIGABYTE}},
+	}
+	laptop.Storages = []*pb.Storage{
+		{Driver: pb.Storage_SSD, Memory: &pb.Memory{Value: 512, Unit: pb.Memory_GIGABYTE}},
+		{Driver: pb.Storage_HDD, Memory: &pb.Memory{Value: 1, Unit: pb.Memory_TERABYTE}},
+	}
+	laptop.Screen = &pb.Screen{
+		SizeInch:   15.6,
+		Resolution: &pb.Screen_Resolution{Width: 1920, Height: 1080},
+		Panel:      pb.Screen_IPS,
+	}
+	laptop.Keyboard = &pb.Keyboard{Layout: pb.Keyboard_QWERTY, Backlit: true}
+	laptop.ReleaseYear = 2018
+	laptop.Weight = &pb.Laptop_WeightLb{WeightLb: 4.4}
+
+	store := service.NewInMemoryLaptopStore()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	gb := func(value uint64) *pb.Memory {
+		return &pb.Memory{Value: value, Unit: pb.Memory_GIGABYTE}
+	}
+
+	testCases := []struct {
+		name    string
+		filter  *pb.Filter
+		matches bool
+	}{
+		{name: "empty", filter: &pb.Filter{}, matches: true},
+		{name: "max_price", filter: &pb.Filter{MaxPriceUsd: proto.Float64(2000)}, matches: true},
+		{name: "max_price_zero", filter: &pb.Filter{MaxPriceUsd: proto.Float64(0)}, matches: false},
+		{name: "brand", filter: &pb.Filter{Brands: []string{"apple", "dell"}}, matches: true},
+		{name: "other_brand", filter: &pb.Filter{Brands: []string{"Lenovo"}}, matches: false},
+		{name: "min_price", filter: &pb.Filter{MinPriceUsd: proto.Float64(2000)}, matches: false},
+		{name: "gpu_brand_and_memory", filter: &pb.Filter{GpuBrands: []string{"AMD"}, MinGpuMemory: gb(8)}, matches: true},
+		{name: "gpu_brand_without_memory", filter: &pb.Filter{GpuBrands: []string{"Nvidia"}, MinGpuMemory: gb(8)}, matches: false},
+		{name: "ssd_only", filter: &pb.Filter{SsdOnly: true}, matches: false},
+		{name: "min_storage", filter: &pb.Filter{MinStorage: gb(1536)}, matches: true},
+		{name: "min_storage_too_big", filter: &pb.Filter{MinStorage: gb(2048)}, matches: false},
+		{name: "screen_size", filter: &pb.Filter{MinScreenSizeInch: proto.Float32(14), MaxScreenSizeInch: proto.Float32(16)}, matches: true},
+		{name: "screen_too_small", filter: &pb.Filter{MinScreenSizeInch: proto.Float32(17)}, matches: false},
+		{name: "min_resolution", filter: &pb.Filter{MinResolution: &pb.Screen_Resolution{Width: 2560}}, matches: false},
+		{name: "panel", filter: &pb.Filter{Panel: pb.Screen_OLED.Enum()}, matches: false},
+		{name: "keyboard", filter: &pb.Filter{KeyboardLayout: pb.Keyboard_QWERTY.Enum(), Backlit: proto.Bool(true)}, matches: true},
+		{name: "not_backlit", filter: &pb.Filter{Backlit: proto.Bool(false)}, matches: false},
+		{name: "keyboard_layout", filter: &pb.Filter{KeyboardLayout: pb.Keyboard_AZERTY.Enum()}, matches: false},
+		{name: "release_year", filter: &pb.Filter{MinReleaseYear: proto.Uint32(2017), MaxReleaseYear: proto.Uint32(2018)}, matches: true},
+		{name: "release_year_too_old", filter: &pb.Filter{MinReleaseYear: proto.Uint32(2019)}, matches: false},
+		{name: "max_weight_from_lb", filter: &pb.Filter{MaxWeightKg: proto.Float64(2.1)}, matches: true},
+		{name: "max_weight_too_light", filter: &pb.Filter{MaxWeightKg: proto.Float64(1.9)}, matches: false},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			found := 0
+			_, err := store.Search(context.Background(), tc.filter, service.SearchOptions{}, func(laptop *pb.Laptop) error {
+				found++
+				return nil
+			})
+			require.NoError(t, err)
+			require.Equal(t, tc.matches, found == 1)
+		})
+	}
+}
+
+func TestInMemoryLaptopStoreSearchText(t *testing.T) {
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+
+	newLaptop := func(brand, name, gpu string) *pb.Laptop {
+		laptop := sample.NewLaptop()
+		laptop.Brand = brand
+		laptop.Name = name
+		laptop.Cpu.Name = "Core i7"
+		laptop.Gpus = []*pb.GPU{{Brand: "Nvidia", Name: gpu}}
+
+		err := store.Save(laptop)
+		require.NoError(t, err)
+		return laptop
+	}
+
+	x1 := newLaptop("Lenovo", "ThinkPad X1", "RTX 2060")
+	x1000 := newLaptop("Lenovo", "ThinkPad X1000", "GTX 1070")
+	xps := newLaptop("Dell", "XPS", "RTX 2070")
+
+	search := func(text string) []string {
+		var ids []string
+		_, err := store.Search(context.Background(), nil, service.SearchOptions{Text: text}, func(laptop *pb.Laptop) error {
+			ids = append(ids, laptop.Id)
+			return nil
+		})
+		require.NoError(t, err)
+		return ids
+	}
+
+	// the whole word X1 is more relevant than the prefix of X1000
+	require.Equal(t, []string{x1.Id, x1000.Id}, search("thinkpad X1"))
+	require.Equal(t, []string{x1000.Id}, search("Lenovo x1000"))
+	require.ElementsMatch(t, []string{x1.Id, x1000.Id}, search("think"))
+	require.Equal(t, []string{x1.Id}, search("RTX 2060"))
+	require.ElementsMatch(t, []string{x1.Id, xps.Id}, search("rtx"))
+	require.Empty(t, search("macbook"))
+
+	xps.Name = "XPS 13 ThinkPad Killer"
+	err := store.Update(xps)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{x1.Id, x1000.Id, xps.Id}, search("thinkpad"))
+
+	err = store.Delete(x1.Id, 0)
+	require.NoError(t, err)
+	require.Empty(t, search("2060"))
+	require.Equal(t, []string{x1000.Id}, search("x1"))
+}
+
+func TestInMemoryLaptopStoreSearchDoesNotBlockWriters(t *testing.T) {
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+
+	var saved []string
+	for i := 0; i < 3; i++ {
+		laptop := sample.NewLaptop()
+		err := store.Save(laptop)
+		require.NoError(t, err)
+		saved = append(saved, laptop.Id)
+	}
+
+	// the slow reader blocks on its first laptop, like a client not reading its stream
+	reading := make(chan struct{})
+	resume := make(chan struct{})
+	type result struct {
+		ids []string
+		err error
+	}
+	searched := make(chan result)
+
+	go func() {
+		var ids []string
+		_, err := store.Search(context.Background(), nil, service.SearchOptions{}, func(laptop *pb.Laptop) error {
+			if len(ids) == 0 {
+				close(reading)
+				<-resume
+			}
+			ids = append(ids, laptop.Id)
+			return nil
+		})
+		searched <- result{ids: ids, err: err}
+	}()
+
+	<-reading
+
+	written := make(chan error)
+	laptop := sample.NewLaptop()
+	go func() {
+		err := store.Save(laptop)
+		if err == nil {
+			err = store.Delete(saved[0], 0)
+		}
+		written <- err
+	}()
+
+	select {
+	case err := <-written:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("writers are blocked by the slow reader")
+	}
+
+	found, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.Equal(t, laptop.Id, found.Id)
+
+	// the slow reader still gets the laptops of the store when it started searching
+	close(resume)
+	res := <-searched
+	require.NoError(t, res.err)
+	require.ElementsMatch(t, saved, res.ids)
+}
+
+func TestInMemoryLaptopStoreFacets(t *testing.T) {
+	t.Parallel()
+
+	store := service.NewInMemoryLaptopStore()
+
+	for i, brand := range []string{"Dell", "Dell", "Apple", "Lenovo"} {
+		laptop := sample.NewLaptop()
+		laptop.Brand = brand
+		laptop.PriceUsd = float64(1000 * (i + 1))
+		laptop.Ram = &pb.Memory{Value: 16, Unit: pb.Memory_GIGABYTE}
+		laptop.Gpus = []*pb.GPU{{Brand: "Nvidia"}, {Brand: "Nvidia"}}
+		laptop.Storages = []*pb.Storage{{Driver: pb.Storage_SSD}, {Driver: pb.Storage_HDD}}
+		laptop.Weight = &pb.Laptop_WeightLb{WeightLb: 2}
+		if brand == "Apple" {
+			laptop.Weight = nil
+		}
+
+		err := store.Save(laptop)
+		require.NoError(t, err)
+	}
+
+	options := service.FacetOptions{PriceBoundsUsd: []float64{2000, 3000}}
+	filter := &pb.Filter{MaxPriceUsd: proto.Float64(3000)}
+
+	facets, err := store.Facets(context.Background(), filter, options)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, facets.GetTotal())
+
+	require.Len(t, facets.GetBrands(), 2)
+	require.Equal(t, "Dell", facets.GetBrands()[0].GetValue())
+	require.EqualValues(t, 2, facets.GetBrands()[0].GetCount())
+	require.Equal(t, "Apple", facets.GetBrands()[1].GetValue())
+
+	// laptops are counted once per distinct GPU brand and storage driver
+	require.Len(t, facets.GetGpuBrands(), 1)
+	require.EqualValues(t, 3, facets.GetGpuBrands()[0].GetCount())
+	require.Len(t, facets.GetStorageDrivers(), 2)
+	require.EqualValues(t, 3, facets.GetStorageDrivers()[0].GetCount())
+
+	price := facets.GetPriceUsd()
+	require.Len(t, price, 3)
+	require.Nil(t, price[0].Min)
+	require.EqualValues(t, 1, price[0].GetCount())
+	require.Equal(t, 2000.0, price[1].GetMin())
+	require.EqualValues(t, 1, price[1].GetCount())
+	require.Nil(t, price[2].Max)
+	require.EqualValues(t, 1, price[2].GetCount())
+
+	// 16GB belongs to the bucket starting at 16GB
+	for _, bucket := range facets.GetRamGb() {
+		if bucket.GetMin() == 16 {
+			require.EqualValues(t, 3, bucket.GetCount())
+		}
+	}
+
+	// laptops without weight are not counted in the weight histogram
+	total := uint32(0)
+	for _, bucket := range facets.GetWeightKg() {
+		total += bucket.GetCount()
+	}
+	require.EqualValues(t, 2, total)
+
+	for _, bounds := range [][]float64{{2, 1}, {1, math.NaN()}, {math.NaN(), 1}, {1, math.Inf(1)}, {math.Inf(-1), 1}} {
+		options.WeightBoundsKg = bounds
+		_, err = store.Facets(context.Background(), filter, options)
+		require.ErrorIs(t, err, service.ErrInvalidFacetOptions)
+	}
+}
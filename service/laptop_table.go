@@ -0,0 +1,63 @@
+package service
+
+import (
+	"otmane/pcbook/pb"
+
+	"github.com/google/btree"
+)
+
+// laptopTable holds the laptops of a store with their indexes in copy-on-write trees,
+// so that a snapshot of it can be taken in constant time and read while the table is written
+type laptopTable struct {
+	laptops *btree.BTreeG[*pb.Laptop]
+	text    *textIndex
+	indexes *laptopIndexes
+}
+
+func lessLaptopID(a, b *pb.Laptop) bool {
+	return a.GetId() < b.GetId()
+}
+
+func newLaptopTable() *laptopTable {
+	return &laptopTable{
+		laptops: btree.NewG(indexDegree, lessLaptopID),
+		text:    newTextIndex(),
+		indexes: newLaptopIndexes(),
+	}
+}
+
+// snapshot returns an immutable copy of the table. It must not be called concurrently
+// with other calls on the table, but the snapshot can then be read without any lock
+// while the table is written. The laptops themselves are never modified, only replaced.
+func (table *laptopTable) snapshot() *laptopTable {
+	return &laptopTable{
+		laptops: table.laptops.Clone(),
+		text:    table.text.clone(),
+		indexes: table.indexes.clone(),
+	}
+}
+
+// get returns the laptop with the ID, or nil if there is none
+func (table *laptopTable) get(id string) *pb.Laptop {
+	laptop, _ := table.laptops.Get(&pb.Laptop{Id: id})
+	return laptop
+}
+
+// put adds the laptop to the table, replacing the stored one if not nil
+func (table *laptopTable) put(laptop *pb.Laptop, stored *pb.Laptop) {
+	if stored != nil {
+		table.text.remove(stored)
+		table.indexes.remove(stored)
+	}
+
+	table.laptops.ReplaceOrInsert(laptop)
+	table.text.add(laptop)
+	table.indexes.add(laptop)
+}
+
+// delete removes the stored laptop from the table
+func (table *laptopTable) delete(stored *pb.Laptop) {
+	table.laptops.Delete(stored)
+	table.text.remove(stored)
+	table.indexes.remove(stored)
+}
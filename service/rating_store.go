@@ -1,6 +1,7 @@
 package service
 
 import (
+	"fmt"
 	"sync"
 )
 
@@ -8,6 +9,12 @@ import (
 type RatingStore interface {
 	// Add adds a new laptop score to the store and returns its rating
 	Add(laptopID string, score float64) (*Rating, error)
+	// Find returns the rating of a laptop
+	Find(laptopID string) (*Rating, error)
+	// List returns the ratings of all the rated laptops by their ID
+	List() (map[string]*Rating, error)
+	// Set replaces the rating of a laptop, to restore it from a snapshot
+	Set(laptopID string, rating *Rating) error
 }
 
 // Rating contains the rating information of a laptop
@@ -47,3 +54,48 @@ func (store *InMemoryRatingStore) Add(laptopID string, score float64) (*Rating,
 
 	return rating, nil
 }
+
+// Find returns the rating of a laptop
+func (store *InMemoryRatingStore) Find(laptopID string) (*Rating, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	rating := store.rating[laptopID]
+	if rating == nil {
+		return nil, fmt.Errorf("rating of laptop %s: %w", laptopID, ErrNotFound)
+	}
+
+	return &Rating{
+		Count: rating.Count,
+		Sum:   rating.Sum,
+	}, nil
+}
+
+// List returns the ratings of all the rated laptops by their ID
+func (store *InMemoryRatingStore) List() (map[string]*Rating, error) {
+	store.mutex.RLock()
+	defer store.mutex.RUnlock()
+
+	ratings := make(map[string]*Rating, len(store.rating))
+	for laptopID, rating := range store.rating {
+		ratings[laptopID] = &Rating{
+			Count: rating.Count,
+			Sum:   rating.Sum,
+		}
+	}
+
+	return ratings, nil
+}
+
+// Set replaces the rating of a laptop
+func (store *InMemoryRatingStore) Set(laptopID string, rating *Rating) error {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.rating[laptopID] = &Rating{
+		Count: rating.Count,
+		Sum:   rating.Sum,
+	}
+
+	return nil
+}
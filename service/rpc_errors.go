@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ERROR_DOMAIN is the domain of the google.rpc.ErrorInfo details of the RPC errors
+const ERROR_DOMAIN = "pcbook"
+
+// errorKinds are the codes and the reasons of the RPC errors caused by each sentinel error
+var errorKinds = []struct {
+	err    error
+	code   codes.Code
+	reason string
+}{
+	{ErrNotFound, codes.NotFound, "NOT_FOUND"},
+	{ErrAlreadyExists, codes.AlreadyExists, "ALREADY_EXISTS"},
+	{ErrConflict, codes.Aborted, "CONFLICT"},
+	{ErrInvalidPageToken, codes.InvalidArgument, "INVALID_PAGE_TOKEN"},
+	{ErrInvalidSortOrder, codes.InvalidArgument, "INVALID_SORT_ORDER"},
+	{ErrInvalidFacetOptions, codes.InvalidArgument, "INVALID_FACET_OPTIONS"},
+	{ErrInvalidFilter, codes.InvalidArgument, "INVALID_FILTER"},
+	{ErrInvalidImage, codes.InvalidArgument, "INVALID_IMAGE"},
+	{context.Canceled, codes.Canceled, "CANCELED"},
+	{context.DeadlineExceeded, codes.DeadlineExceeded, "DEADLINE_EXCEEDED"},
+}
+
+// resource is the record an RPC error is about, the zero value when there is none
+type resource struct {
+	kind string
+	id   string
+}
+
+func laptopResource(id string) resource {
+	return resource{kind: "laptop", id: id}
+}
+
+func imageResource(id string) resource {
+	return resource{kind: "image", id: id}
+}
+
+// toRPCError converts an error to a gRPC status error with the code of the sentinel error it wraps,
+// or codes.Internal, and the formatted message followed by the error. Its details are a
+// google.rpc.ErrorInfo with the reason of the sentinel error, and a google.rpc.ResourceInfo
+// of the resource if the error is about it. Status errors are returned unchanged.
+func toRPCError(err error, res resource, format string, args ...interface{}) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code, reason := codes.Internal, "INTERNAL"
+	for _, kind := range errorKinds {
+		if errors.Is(err, kind.err) {
+			code, reason = kind.code, kind.reason
+			break
+		}
+	}
+
+	message := fmt.Sprintf("%s: %v", fmt.Sprintf(format, args...), err)
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{Reason: reason, Domain: ERROR_DOMAIN},
+	}
+
+	isAboutResource := code == codes.NotFound || code == codes.AlreadyExists || code == codes.Aborted
+	if res.kind != "" && isAboutResource {
+		details = append(details, &errdetails.ResourceInfo{
+			ResourceType: res.kind,
+			ResourceName: res.id,
+			Description:  err.Error(),
+		})
+	}
+
+	return newStatusError(code, message, details...)
+}
+
+// invalidArgument returns an InvalidArgument status error with a google.rpc.BadRequest detail
+// describing why the field of the request is invalid
+func invalidArgument(field string, format string, args ...interface{}) error {
+	message := fmt.Sprintf(format, args...)
+
+	return newStatusError(codes.InvalidArgument, message, &errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: message},
+		},
+	})
+}
+
+// newRPCError returns a status error with the code and the formatted message, and a google.rpc.ErrorInfo
+// detail with the reason, for the errors which are not caused by a sentinel error
+func newRPCError(code codes.Code, reason string, format string, args ...interface{}) error {
+	return newStatusError(code, fmt.Sprintf(format, args...), &errdetails.ErrorInfo{Reason: reason, Domain: ERROR_DOMAIN})
+}
+
+// streamError converts an error sending or receiving a message of a stream to a status error with the
+// code and the reason of the context error if the stream ended with its context, and codes.Unknown otherwise
+func streamError(err error, format string, args ...interface{}) error {
+	code, reason := codes.Unknown, "STREAM_ERROR"
+	switch {
+	case errors.Is(err, context.Canceled) || status.Code(err) == codes.Canceled:
+		code, reason = codes.Canceled, "CANCELED"
+	case errors.Is(err, context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded:
+		code, reason = codes.DeadlineExceeded, "DEADLINE_EXCEEDED"
+	}
+
+	return newRPCError(code, reason, "%s: %v", fmt.Sprintf(format, args...), err)
+}
+
+func newStatusError(code codes.Code, message string, details ...protoadapt.MessageV1) error {
+	st := status.New(code, message)
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+
+	return withDetails.Err()
+}
@@ -0,0 +1,268 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"otmane/pcbook/pb"
+	"otmane/pcbook/sample"
+	"otmane/pcbook/service"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestClientErrorDetails(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	ratingStore := service.NewInMemoryRatingStore()
+	imageStore := service.NewDiskImageStore(t.TempDir())
+
+	laptop := sample.NewLaptop()
+	err := laptopStore.Save(laptop)
+	require.NoError(t, err)
+
+	serverAddress := startTestLaptopServer(t, laptopStore, imageStore, ratingStore)
+	laptopClient := newTestLaptopClient(t, serverAddress)
+
+	missingID := sample.NewLaptop().Id
+
+	testCases := []struct {
+		name     string
+		call     func(ctx context.Context) error
+		code     codes.Code
+		reason   string
+		resource string
+		field    string
+	}{
+		{
+			name: "get_missing_laptop",
+			call: func(ctx context.Context) error {
+				_, err := laptopClient.GetLaptop(ctx, &pb.GetLaptopRequest{Id: missingID})
+				return err
+			},
+			code:     codes.NotFound,
+			reason:   "NOT_FOUND",
+			resource: missingID,
+		},
+		{
+			name: "create_existing_laptop",
+			call: func(ctx context.Context) error {
+				_, err := laptopClient.CreateLaptop(ctx, &pb.CreateLaptopRequest{Laptop: laptop})
+				return err
+			},
+			code:     codes.AlreadyExists,
+			reason:   "ALREADY_EXISTS",
+			resource: laptop.Id,
+		},
+		{
+			name: "create_invalid_id",
+			call: func(ctx context.Context) error {
+				other := sample.NewLaptop()
+				other.Id = "invalid-uuid"
+				_, err := laptopClient.CreateLaptop(ctx, &pb.CreateLaptopRequest{Laptop: other})
+				return err
+			},
+			code:  codes.InvalidArgument,
+			field: "laptop.id",
+		},
+		{
+			name: "update_stale_revision",
+			call: func(ctx context.Context) error {
+				_, err := laptopClient.UpdateLaptop(ctx, &pb.UpdateLaptopRequest{Laptop: laptop, ExpectedRevision: 42})
+				return err
+			},
+			code:     codes.Aborted,
+			reason:   "CONFLICT",
+			resource: laptop.Id,
+		},
+		{
+			name: "delete_missing_laptop",
+			call: func(ctx context.Context) error {
+				_, err := laptopClient.DeleteLaptop(ctx, &pb.DeleteLaptopRequest{Id: missingID})
+				return err
+			},
+			code:     codes.NotFound,
+			reason:   "NOT_FOUND",
+			resource: missingID,
+		},
+		{
+			name: "search_invalid_page_token",
+			call: func(ctx context.Context) error {
+				stream, err := laptopClient.SearchLaptop(ctx, &pb.SearchLaptopRequest{PageToken: "invalid"})
+				if err != nil {
+					return err
+				}
+				_, err = stream.Recv()
+				return err
+			},
+			code:   codes.InvalidArgument,
+			reason: "INVALID_PAGE_TOKEN",
+		},
+		{
+			name: "upload_image_missing_laptop",
+			call: func(ctx context.Context) error {
+				stream, err := laptopClient.UploadImage(ctx)
+				if err != nil {
+					return err
+				}
+				err = stream.Send(&pb.UploadImageRequest{
+					Data: &pb.UploadImageRequest_Info{Info: &pb.ImageInfo{LaptopId: missingID, ImageType: ".jpg"}},
+				})
+				if err != nil {
+					return err
+				}
+				_, err = stream.CloseAndRecv()
+				return err
+			},
+			code:     codes.NotFound,
+			reason:   "NOT_FOUND",
+			resource: missingID,
+		},
+		{
+			name: "upload_invalid_image",
+			call: func(ctx context.Context) error {
+				stream, err := laptopClient.UploadImage(ctx)
+				if err != nil {
+					return err
+				}
+				err = stream.Send(&pb.UploadImageRequest{
+					Data: &pb.UploadImageRequest_Info{Info: &pb.ImageInfo{LaptopId: laptop.Id, ImageType: ".jpg"}},
+				})
+				if err != nil {
+					return err
+				}
+				err = stream.Send(&pb.UploadImageRequest{
+					Data: &pb.UploadImageRequest_ChunkData{ChunkData: []byte("not an image")},
+				})
+				if err != nil {
+					return err
+				}
+				_, err = stream.CloseAndRecv()
+				return err
+			},
+			code:  codes.InvalidArgument,
+			field: "chunk_data",
+		},
+		{
+			name: "rate_missing_laptop",
+			call: func(ctx context.Context) error {
+				stream, err := laptopClient.RateLaptop(ctx)
+				if err != nil {
+					return err
+				}
+				err = stream.Send(&pb.RateLaptopRequest{LaptopId: missingID, Score: 8})
+				if err != nil {
+					return err
+				}
+				_, err = stream.Recv()
+				return err
+			},
+			code:     codes.NotFound,
+			reason:   "NOT_FOUND",
+			resource: missingID,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tc.call(context.Background())
+			st, ok := status.FromError(err)
+			require.True(t, ok)
+			require.Equal(t, tc.code, st.Code())
+
+			var reason, resource, field string
+			for _, detail := range st.Details() {
+				switch detail := detail.(type) {
+				case *errdetails.ErrorInfo:
+					require.Equal(t, service.ERROR_DOMAIN, detail.GetDomain())
+					reason = detail.GetReason()
+				case *errdetails.ResourceInfo:
+					require.Equal(t, "laptop", detail.GetResourceType())
+					resource = detail.GetResourceName()
+				case *errdetails.BadRequest:
+					require.Len(t, detail.GetFieldViolations(), 1)
+					field = detail.GetFieldViolations()[0].GetField()
+				}
+			}
+
+			require.Equal(t, tc.reason, reason)
+			require.Equal(t, tc.resource, resource)
+			require.Equal(t, tc.field, field)
+		})
+	}
+}
+
+func TestServerErrorDetails(t *testing.T) {
+	t.Parallel()
+
+	laptopServer := service.NewLaptopServer(service.NewInMemoryLaptopStore(), nil, nil)
+	authServer := service.NewAuthServer(service.NewInMemoryUserStore(), *service.NewJWTManager("secret", time.Minute))
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	expired, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+
+	testCases := []struct {
+		name   string
+		call   func() error
+		code   codes.Code
+		reason string
+	}{
+		{
+			name: "create_laptop_canceled",
+			call: func() error {
+				_, err := laptopServer.CreateLaptop(canceled, &pb.CreateLaptopRequest{Laptop: sample.NewLaptop()})
+				return err
+			},
+			code:   codes.Canceled,
+			reason: "CANCELED",
+		},
+		{
+			name: "create_laptop_deadline_exceeded",
+			call: func() error {
+				_, err := laptopServer.CreateLaptop(expired, &pb.CreateLaptopRequest{Laptop: sample.NewLaptop()})
+				return err
+			},
+			code:   codes.DeadlineExceeded,
+			reason: "DEADLINE_EXCEEDED",
+		},
+		{
+			name: "login_unknown_user",
+			call: func() error {
+				_, err := authServer.Login(context.Background(), &pb.LoginRequest{Username: "unknown", Password: "secret"})
+				return err
+			},
+			code:   codes.NotFound,
+			reason: "INVALID_CREDENTIALS",
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			st, ok := status.FromError(tc.call())
+			require.True(t, ok)
+			require.Equal(t, tc.code, st.Code())
+
+			require.Len(t, st.Details(), 1)
+			info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+			require.True(t, ok)
+			require.Equal(t, service.ERROR_DOMAIN, info.GetDomain())
+			require.Equal(t, tc.reason, info.GetReason())
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"otmane/pcbook/pb"
+)
+
+// ShardedLaptopStore stores laptops in memory, partitioned by the hash of their ID across shards
+// which are locked independently, so that writes to different shards do not wait for each other.
+// Searches run on all the shards concurrently.
+type ShardedLaptopStore struct {
+	shards []*InMemoryLaptopStore
+}
+
+// NewShardedLaptopStore returns a new ShardedLaptopStore with shardCount shards, which must be positive
+func NewShardedLaptopStore(shardCount int) *ShardedLaptopStore {
+	shards := make([]*InMemoryLaptopStore, shardCount)
+	for i := range shards {
+		shards[i] = NewInMemoryLaptopStore()
+	}
+
+	return &ShardedLaptopStore{shards: shards}
+}
+
+// shard returns the shard storing the laptop with the ID
+func (store *ShardedLaptopStore) shard(id string) *InMemoryLaptopStore {
+	hash := fnv.New32a()
+	hash.Write([]byte(id))
+
+	return store.shards[hash.Sum32()%uint32(len(store.shards))]
+}
+
+// Save saves the laptop to its shard
+func (store *ShardedLaptopStore) Save(laptop *pb.Laptop) error {
+	return store.shard(laptop.Id).Save(laptop)
+}
+
+// Find searches for a laptop by its ID
+func (store *ShardedLaptopStore) Find(id string) (*pb.Laptop, error) {
+	return store.shard(id).Find(id)
+}
+
+// Update replaces an existing laptop in its shard
+func (store *ShardedLaptopStore) Update(laptop *pb.Laptop) error {
+	return store.shard(laptop.Id).Update(laptop)
+}
+
+// Delete removes a laptop from its shard by its ID
+func (store *ShardedLaptopStore) Delete(id string, revision uint64) error {
+	return store.shard(id).Delete(id, revision)
+}
+
+// Search searches for laptops with filter in every shard, and returns one by one via found function
+// the first page of their merged results in the order of the options
+func (store *ShardedLaptopStore) Search(
+	ctx context.Context,
+	filter *pb.Filter,
+	options SearchOptions,
+	found func(laptop *pb.Laptop) error,
+) (string, error) {
+	cursor, err := options.validate()
+	if err != nil {
+		return "", err
+	}
+
+	err = validateFilter(filter)
+	if err != nil {
+		return "", err
+	}
+
+	shardHits := make([][]searchHit, len(store.shards))
+	err = store.forEachShard(ctx, func(ctx context.Context, i int) error {
+		hits, err := store.shards[i].snapshot().searchHits(ctx, filter, options, cursor, true)
+		if err != nil {
+			return err
+		}
+
+		// only the first page of each shard can be part of the first page of the merged results
+		sortHits(hits, options)
+		if options.PageSize > 0 && len(hits) > options.PageSize+1 {
+			hits = hits[:options.PageSize+1]
+		}
+
+		shardHits[i] = hits
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var hits []searchHit
+	for _, shard := range shardHits {
+		hits = append(hits, shard...)
+	}
+
+	sortHits(hits, options)
+	return sendPage(hits, options, found)
+}
+
+// Facets counts the laptops of every shard matching the filter by value of their facets
+func (store *ShardedLaptopStore) Facets(ctx context.Context, filter *pb.Filter, options FacetOptions) (*pb.Facets, error) {
+	err := options.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	counters := make([]*facetCounter, len(store.shards))
+	err = store.forEachShard(ctx, func(ctx context.Context, i int) error {
+		counter := newFacetCounter(options)
+		err := store.shards[i].snapshot().forEachCandidate(filter, nil, true, func(laptop *pb.Laptop) error {
+			if ctx.Err() != nil {
+				return fmt.Errorf("dropping the facets: %w", ctx.Err())
+			}
+			if isQualified(filter, laptop) {
+				counter.add(laptop)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		counters[i] = counter
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counter := newFacetCounter(options)
+	for _, other := range counters {
+		counter.merge(other)
+	}
+
+	return counter.facets(), nil
+}
+
+// forEachShard calls run concurrently with the index of each shard, and returns the first error
+// returned by one of them once they all returned. The context given to run is canceled on error.
+func (store *ShardedLaptopStore) forEachShard(ctx context.Context, run func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	var once sync.Once
+	var firstErr error
+
+	for i := range store.shards {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			err := run(ctx, i)
+			if err != nil {
+				once.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	return firstErr
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"otmane/pcbook/pb"
+	"otmane/pcbook/sample"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestShardedLaptopStoreSearch(t *testing.T) {
+	t.Parallel()
+
+	sharded := NewShardedLaptopStore(8)
+	memory := NewInMemoryLaptopStore()
+	for i := 0; i < 300; i++ {
+		laptop := sample.NewLaptop()
+		require.NoError(t, sharded.Save(laptop))
+		require.NoError(t, memory.Save(laptop))
+	}
+
+	testCases := []struct {
+		name    string
+		filter  *pb.Filter
+		options SearchOptions
+	}{
+		{name: "all", options: SearchOptions{PageSize: 20}},
+		{name: "filter", filter: &pb.Filter{MaxPriceUsd: proto.Float64(2500)}, options: SearchOptions{PageSize: 7}},
+		{name: "text", options: SearchOptions{Text: "thinkpad", PageSize: 5}},
+		{
+			name: "sorted",
+			options: SearchOptions{
+				SortBy:   []*pb.SortOrder{{Key: pb.SortOrder_PRICE_USD, Descending: true}},
+				PageSize: 13,
+			},
+		},
+		{name: "unpaged", options: SearchOptions{SortBy: []*pb.SortOrder{{Key: pb.SortOrder_RAM}}}},
+	}
+
+	// searchPages returns the IDs of the laptops of every page of the search
+	searchPages := func(store LaptopStore, filter *pb.Filter, options SearchOptions) []string {
+		var ids []string
+		for {
+			token, err := store.Search(context.Background(), filter, options, func(laptop *pb.Laptop) error {
+				ids = append(ids, laptop.GetId())
+				return nil
+			})
+			require.NoError(t, err)
+			if token == "" {
+				return ids
+			}
+			options.PageToken = token
+		}
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			expected := searchPages(memory, tc.filter, tc.options)
+			require.NotEmpty(t, expected)
+			require.Equal(t, expected, searchPages(sharded, tc.filter, tc.options))
+
+			expectedFacets, err := memory.Facets(context.Background(), tc.filter, FacetOptions{})
+			require.NoError(t, err)
+			facets, err := sharded.Facets(context.Background(), tc.filter, FacetOptions{})
+			require.NoError(t, err)
+			require.True(t, proto.Equal(expectedFacets, facets))
+		})
+	}
+}
+
+func TestShardedLaptopStoreSearchCanceled(t *testing.T) {
+	t.Parallel()
+
+	store := NewShardedLaptopStore(4)
+	for i := 0; i < 100; i++ {
+		require.NoError(t, store.Save(sample.NewLaptop()))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := store.Search(ctx, nil, SearchOptions{}, func(laptop *pb.Laptop) error {
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = store.Facets(ctx, nil, FacetOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+// BenchmarkParallelLaptopStore compares the throughput of the single lock and the sharded stores
+// when saving and finding laptops from many goroutines
+func BenchmarkParallelLaptopStore(b *testing.B) {
+	stores := []struct {
+		name     string
+		newStore func() LaptopStore
+	}{
+		{"InMemory", func() LaptopStore { return NewInMemoryLaptopStore() }},
+		{"Sharded/8", func() LaptopStore { return NewShardedLaptopStore(8) }},
+		{"Sharded/64", func() LaptopStore { return NewShardedLaptopStore(64) }},
+	}
+
+	for _, s := range stores {
+		b.Run(fmt.Sprintf("Save/%s", s.name), func(b *testing.B) {
+			store := s.newStore()
+			laptops := make(chan *pb.Laptop, b.N)
+			for i := 0; i < b.N; i++ {
+				laptops <- sample.NewLaptop()
+			}
+			close(laptops)
+
+			b.ResetTimer()
+			b.RunParallel(func(p *testing.PB) {
+				for p.Next() {
+					err := store.Save(<-laptops)
+					if err != nil {
+						b.Error(err)
+					}
+				}
+			})
+		})
+
+		b.Run(fmt.Sprintf("FindAndUpdate/%s", s.name), func(b *testing.B) {
+			store := s.newStore()
+			ids := make([]string, 1000)
+			for i := range ids {
+				laptop := sample.NewLaptop()
+				require.NoError(b, store.Save(laptop))
+				ids[i] = laptop.GetId()
+			}
+
+			b.ResetTimer()
+			b.RunParallel(func(p *testing.PB) {
+				for i := 0; p.Next(); i++ {
+					laptop, err := store.Find(ids[i%len(ids)])
+					if err != nil {
+						b.Error(err)
+						return
+					}
+
+					// one request out of four writes the laptop it found
+					if i%4 == 0 {
+						laptop.Revision = 0
+						err = store.Update(laptop)
+						if err != nil {
+							b.Error(err)
+						}
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"otmane/pcbook/pb"
+	"otmane/pcbook/serializer"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// SNAPSHOT_VERSION is the version of the snapshot format written by the server
+const SNAPSHOT_VERSION = 1
+
+// ErrInvalidSnapshot is returned when reading a snapshot which is malformed, truncated or of an unknown version
+var ErrInvalidSnapshot = errors.New("invalid snapshot")
+
+// ErrNotEmpty is returned when restoring a snapshot to stores which already have records
+var ErrNotEmpty = errors.New("stores are not empty")
+
+// Snapshot is a copy of the laptops, ratings, users and image info of the stores at a point in time
+type Snapshot struct {
+	CreatedAt time.Time
+	Laptops   []*pb.Laptop
+	Ratings   map[string]*Rating
+	Users     []*User
+	Images    map[string]*ImageInfo
+}
+
+// TakeSnapshot copies the records of the stores, which must not be written until it returns
+// for the snapshot to be consistent
+func TakeSnapshot(
+	ctx context.Context,
+	laptopStore LaptopStore,
+	ratingStore RatingStore,
+	userStore UserStore,
+	imageStore ImageStore,
+) (*Snapshot, error) {
+	snapshot := &Snapshot{CreatedAt: time.Now()}
+
+	_, err := laptopStore.Search(ctx, nil, SearchOptions{}, func(laptop *pb.Laptop) error {
+		snapshot.Laptops = append(snapshot.Laptops, laptop)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot copy laptops: %w", err)
+	}
+
+	snapshot.Ratings, err = ratingStore.List()
+	if err != nil {
+		return nil, fmt.Errorf("cannot copy ratings: %w", err)
+	}
+
+	snapshot.Users, err = userStore.List()
+	if err != nil {
+		return nil, fmt.Errorf("cannot copy users: %w", err)
+	}
+
+	snapshot.Images, err = imageStore.List()
+	if err != nil {
+		return nil, fmt.Errorf("cannot copy images: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+// Restore saves the records of the snapshot to the stores, which must be empty: nothing is saved
+// if one of them is not. The laptops are saved again, so their revisions start over.
+func (snapshot *Snapshot) Restore(
+	laptopStore LaptopStore,
+	ratingStore RatingStore,
+	userStore UserStore,
+	imageStore ImageStore,
+) error {
+	err := checkEmpty(laptopStore, ratingStore, userStore, imageStore)
+	if err != nil {
+		return err
+	}
+
+	for _, laptop := range snapshot.Laptops {
+		err := laptopStore.Save(proto.Clone(laptop).(*pb.Laptop))
+		if err != nil {
+			return fmt.Errorf("cannot restore laptop: %w", err)
+		}
+	}
+
+	for laptopID, rating := range snapshot.Ratings {
+		err := ratingStore.Set(laptopID, rating)
+		if err != nil {
+			return fmt.Errorf("cannot restore rating: %w", err)
+		}
+	}
+
+	for _, user := range snapshot.Users {
+		err := userStore.Save(user.Clone())
+		if err != nil {
+			return fmt.Errorf("cannot restore user: %w", err)
+		}
+	}
+
+	for imageID, info := range snapshot.Images {
+		err := imageStore.Set(imageID, info)
+		// the image files are not part of the snapshot, the images whose file is gone are not restored
+		if errors.Is(err, ErrNotFound) {
+			log.Printf("image %s of the snapshot is not restored: %v", imageID, err)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("cannot restore image: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// checkEmpty returns ErrNotEmpty if one of the stores has a record
+func checkEmpty(laptopStore LaptopStore, ratingStore RatingStore, userStore UserStore, imageStore ImageStore) error {
+	laptops := 0
+	_, err := laptopStore.Search(context.Background(), nil, SearchOptions{PageSize: 1}, func(laptop *pb.Laptop) error {
+		laptops++
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("cannot list laptops: %w", err)
+	}
+
+	ratings, err := ratingStore.List()
+	if err != nil {
+		return fmt.Errorf("cannot list ratings: %w", err)
+	}
+
+	users, err := userStore.List()
+	if err != nil {
+		return fmt.Errorf("cannot list users: %w", err)
+	}
+
+	images, err := imageStore.List()
+	if err != nil {
+		return fmt.Errorf("cannot list images: %w", err)
+	}
+
+	var stores []string
+	for name, count := range map[string]int{"laptop": laptops, "rating": len(ratings), "user": len(users), "image": len(images)} {
+		if count > 0 {
+			stores = append(stores, name)
+		}
+	}
+	if len(stores) > 0 {
+		sort.Strings(stores)
+		return fmt.Errorf("%w: the %s stores have records", ErrNotEmpty, strings.Join(stores, ", "))
+	}
+
+	return nil
+}
+
+// ForEachRecord calls send with the header of the snapshot, followed by its laptops, ratings,
+// users and images in the order of their ID
+func (snapshot *Snapshot) ForEachRecord(send func(record *pb.SnapshotRecord) error) error {
+	header := &pb.SnapshotHeader{
+		Version:     SNAPSHOT_VERSION,
+		CreatedAt:   timestamppb.New(snapshot.CreatedAt),
+		LaptopCount: uint32(len(snapshot.Laptops)),
+		RatingCount: uint32(len(snapshot.Ratings)),
+		UserCount:   uint32(len(snapshot.Users)),
+		ImageCount:  uint32(len(snapshot.Images)),
+	}
+	err := send(&pb.SnapshotRecord{Record: &pb.SnapshotRecord_Header{Header: header}})
+	if err != nil {
+		return err
+	}
+
+	for _, laptop := range snapshot.Laptops {
+		err := send(&pb.SnapshotRecord{Record: &pb.SnapshotRecord_Laptop{Laptop: laptop}})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, laptopID := range sortedKeys(snapshot.Ratings) {
+		rating := snapshot.Ratings[laptopID]
+		err := send(&pb.SnapshotRecord{Record: &pb.SnapshotRecord_Rating{Rating: &pb.SnapshotRating{
+			LaptopId: laptopID,
+			Count:    rating.Count,
+			Sum:      rating.Sum,
+		}}})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, user := range snapshot.Users {
+		err := send(&pb.SnapshotRecord{Record: &pb.SnapshotRecord_User{User: &pb.SnapshotUser{
+			Username:       user.Username,
+			HashedPassword: user.HashedPassword,
+			Role:           user.Role,
+		}}})
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, imageID := range sortedKeys(snapshot.Images) {
+		info := snapshot.Images[imageID]
+		err := send(&pb.SnapshotRecord{Record: &pb.SnapshotRecord_Image{Image: &pb.SnapshotImage{
+			Id:             imageID,
+			LaptopId:       info.LaptopID,
+			ImageType:      info.Type,
+			Path:           info.Path,
+			Size:           uint64(info.Size),
+			ChecksumSha256: info.Checksum,
+			CreatedAt:      timestamppb.New(info.CreatedAt),
+		}}})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadSnapshotFile reads a whole snapshot file, checking its version and that it has all the records
+// counted by its header
+func ReadSnapshotFile(filename string) (*Snapshot, error) {
+	var header *pb.SnapshotHeader
+	snapshot := &Snapshot{
+		Ratings: make(map[string]*Rating),
+		Images:  make(map[string]*ImageInfo),
+	}
+
+	newRecord := func() proto.Message { return &pb.SnapshotRecord{} }
+	err := serializer.ReadProtobufFromDelimitedFile(filename, newRecord, func(message proto.Message) error {
+		record := message.(*pb.SnapshotRecord)
+		if header == nil {
+			header = record.GetHeader()
+			if header == nil {
+				return fmt.Errorf("%w: missing header", ErrInvalidSnapshot)
+			}
+			if header.GetVersion() != SNAPSHOT_VERSION {
+				return fmt.Errorf("%w: unknown version %d", ErrInvalidSnapshot, header.GetVersion())
+			}
+
+			snapshot.CreatedAt = header.GetCreatedAt().AsTime()
+			return nil
+		}
+
+		return snapshot.add(record)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("%w: empty file", ErrInvalidSnapshot)
+	}
+
+	if len(snapshot.Laptops) != int(header.GetLaptopCount()) ||
+		len(snapshot.Ratings) != int(header.GetRatingCount()) ||
+		len(snapshot.Users) != int(header.GetUserCount()) ||
+		len(snapshot.Images) != int(header.GetImageCount()) {
+		return nil, fmt.Errorf("%w: records do not match the counts of the header", ErrInvalidSnapshot)
+	}
+
+	err = snapshot.checkUnique()
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// checkUnique checks that the snapshot has a single laptop with each ID and a single user with each username,
+// so that restoring it to empty stores cannot fail half way with ErrAlreadyExists
+func (snapshot *Snapshot) checkUnique() error {
+	laptopIDs := make(map[string]bool, len(snapshot.Laptops))
+	for _, laptop := range snapshot.Laptops {
+		if laptopIDs[laptop.GetId()] {
+			return fmt.Errorf("%w: duplicate laptop %s", ErrInvalidSnapshot, laptop.GetId())
+		}
+		laptopIDs[laptop.GetId()] = true
+	}
+
+	usernames := make(map[string]bool, len(snapshot.Users))
+	for _, user := range snapshot.Users {
+		if usernames[user.Username] {
+			return fmt.Errorf("%w: duplicate user %s", ErrInvalidSnapshot, user.Username)
+		}
+		usernames[user.Username] = true
+	}
+
+	return nil
+}
+
+// add adds the record following the header to the snapshot
+func (snapshot *Snapshot) add(record *pb.SnapshotRecord) error {
+	switch record := record.GetRecord().(type) {
+	case *pb.SnapshotRecord_Laptop:
+		snapshot.Laptops = append(snapshot.Laptops, record.Laptop)
+	case *pb.SnapshotRecord_Rating:
+		snapshot.Ratings[record.Rating.GetLaptopId()] = &Rating{
+			Count: record.Rating.GetCount(),
+			Sum:   record.Rating.GetSum(),
+		}
+	case *pb.SnapshotRecord_User:
+		snapshot.Users = append(snapshot.Users, &User{
+			Username:       record.User.GetUsername(),
+			HashedPassword: record.User.GetHashedPassword(),
+			Role:           record.User.GetRole(),
+		})
+	case *pb.SnapshotRecord_Image:
+		info := &ImageInfo{
+			LaptopID: record.Image.GetLaptopId(),
+			Type:     record.Image.GetImageType(),
+			Path:     record.Image.GetPath(),
+			Size:     int64(record.Image.GetSize()),
+			Checksum: record.Image.GetChecksumSha256(),
+		}
+		// snapshots taken before images had metadata have no creation time
+		if record.Image.GetCreatedAt() != nil {
+			info.CreatedAt = record.Image.GetCreatedAt().AsTime()
+		}
+		snapshot.Images[record.Image.GetId()] = info
+	default:
+		return fmt.Errorf("%w: unexpected record %T", ErrInvalidSnapshot, record)
+	}
+
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
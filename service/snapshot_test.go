@@ -0,0 +1,187 @@
+package service_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"otmane/pcbook/client"
+	"otmane/pcbook/pb"
+	"otmane/pcbook/sample"
+	"otmane/pcbook/serializer"
+	"otmane/pcbook/service"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestClientSnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	laptopStore := service.NewInMemoryLaptopStore()
+	ratingStore := service.NewInMemoryRatingStore()
+	userStore := service.NewInMemoryUserStore()
+	imageFolder := t.TempDir()
+	imageStore := service.NewDiskImageStore(imageFolder)
+
+	laptops := []*pb.Laptop{sample.NewLaptop(), sample.NewLaptop(), sample.NewLaptop()}
+	for _, laptop := range laptops {
+		require.NoError(t, laptopStore.Save(laptop))
+	}
+	_, err := ratingStore.Add(laptops[0].Id, 8)
+	require.NoError(t, err)
+	_, err = ratingStore.Add(laptops[0].Id, 6)
+	require.NoError(t, err)
+	require.NoError(t, userStore.Save(&service.User{Username: "admin", HashedPassword: "hash", Role: "admin"}))
+	imageID, err := imageStore.Save(laptops[1].Id, ".jpg", strings.NewReader("image"))
+	require.NoError(t, err)
+	removedImageID, err := imageStore.Save(laptops[1].Id, ".png", strings.NewReader("image"))
+	require.NoError(t, err)
+
+	laptopServer := service.NewLaptopServer(laptopStore, imageStore, ratingStore)
+	serverAddress := startTestAdminServer(t, service.NewAdminServer(laptopServer, userStore))
+
+	conn, err := grpc.Dial(serverAddress, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	filename := filepath.Join(t.TempDir(), "snapshot.bin")
+	err = client.NewAdminClient(conn).Snapshot(filename)
+	require.NoError(t, err)
+
+	snapshot, err := service.ReadSnapshotFile(filename)
+	require.NoError(t, err)
+
+	restoredLaptops := service.NewInMemoryLaptopStore()
+	restoredRatings := service.NewInMemoryRatingStore()
+	restoredUsers := service.NewInMemoryUserStore()
+	// the image files are not part of the snapshot, they are restored from the same folder
+	require.NoError(t, os.Remove(filepath.Join(imageFolder, removedImageID+".png")))
+	restoredImages := service.NewDiskImageStore(imageFolder)
+	err = snapshot.Restore(restoredLaptops, restoredRatings, restoredUsers, restoredImages)
+	require.NoError(t, err)
+
+	for _, laptop := range laptops {
+		restored, err := restoredLaptops.Find(laptop.Id)
+		require.NoError(t, err)
+		requireSameLaptop(t, laptop, restored)
+	}
+
+	rating, err := restoredRatings.Find(laptops[0].Id)
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: 2, Sum: 14}, rating)
+
+	user, err := restoredUsers.Find("admin")
+	require.NoError(t, err)
+	require.Equal(t, "hash", user.HashedPassword)
+
+	images, err := restoredImages.List()
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	require.Equal(t, laptops[1].Id, images[imageID].LaptopID)
+	require.NotContains(t, images, removedImageID)
+}
+
+func TestSnapshotRestoreNotEmpty(t *testing.T) {
+	t.Parallel()
+
+	snapshot := &service.Snapshot{
+		Laptops: []*pb.Laptop{sample.NewLaptop()},
+		Ratings: map[string]*service.Rating{},
+		Users:   []*service.User{{Username: "admin", HashedPassword: "hash", Role: "admin"}},
+		Images:  map[string]*service.ImageInfo{},
+	}
+
+	// users seeded by a previous run of the server
+	laptopStore := service.NewInMemoryLaptopStore()
+	userStore := service.NewInMemoryUserStore()
+	require.NoError(t, userStore.Save(&service.User{Username: "admin", HashedPassword: "other", Role: "admin"}))
+
+	err := snapshot.Restore(laptopStore, service.NewInMemoryRatingStore(), userStore, service.NewDiskImageStore(t.TempDir()))
+	require.ErrorIs(t, err, service.ErrNotEmpty)
+
+	// nothing was restored
+	_, err = laptopStore.Find(snapshot.Laptops[0].Id)
+	require.ErrorIs(t, err, service.ErrNotFound)
+}
+
+func TestReadSnapshotFileInvalid(t *testing.T) {
+	t.Parallel()
+
+	header := &pb.SnapshotHeader{Version: service.SNAPSHOT_VERSION, LaptopCount: 2}
+	laptop := &pb.SnapshotRecord{Record: &pb.SnapshotRecord_Laptop{Laptop: sample.NewLaptop()}}
+
+	testCases := []struct {
+		name    string
+		records []proto.Message
+	}{
+		{
+			name:    "empty",
+			records: nil,
+		},
+		{
+			name:    "missing_header",
+			records: []proto.Message{laptop},
+		},
+		{
+			name: "unknown_version",
+			records: []proto.Message{
+				&pb.SnapshotRecord{Record: &pb.SnapshotRecord_Header{Header: &pb.SnapshotHeader{Version: service.SNAPSHOT_VERSION + 1}}},
+			},
+		},
+		{
+			name: "duplicate_laptop",
+			records: []proto.Message{
+				&pb.SnapshotRecord{Record: &pb.SnapshotRecord_Header{Header: header}},
+				laptop,
+				laptop,
+			},
+		},
+		{
+			name: "truncated",
+			records: []proto.Message{
+				&pb.SnapshotRecord{Record: &pb.SnapshotRecord_Header{Header: header}},
+				laptop,
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			filename := filepath.Join(t.TempDir(), "snapshot.bin")
+			writer, err := serializer.CreateDelimitedFile(filename)
+			require.NoError(t, err)
+			for _, record := range tc.records {
+				require.NoError(t, writer.Write(record))
+			}
+			require.NoError(t, writer.Commit())
+
+			_, err = service.ReadSnapshotFile(filename)
+			require.ErrorIs(t, err, service.ErrInvalidSnapshot)
+		})
+	}
+
+	_, err := service.ReadSnapshotFile(filepath.Join(t.TempDir(), "missing.bin"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func startTestAdminServer(t *testing.T, adminServer *service.AdminServer) string {
+	grpcServer := grpc.NewServer()
+	pb.RegisterAdminServiceServer(grpcServer, adminServer)
+
+	listener, err := net.Listen("tcp", ":0")
+	require.NoError(t, err)
+
+	go grpcServer.Serve(listener)
+	t.Cleanup(grpcServer.Stop)
+
+	return listener.Addr().String()
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// sqliteMigrations are the statements creating the schema of the SQLite stores, one per version.
+// A database at version n has run the first n migrations. New migrations must be appended.
+var sqliteMigrations = []string{
+	`
+	CREATE TABLE laptops (
+		id               TEXT PRIMARY KEY,
+		revision         INTEGER NOT NULL,
+		data             BLOB NOT NULL,
+		brand            TEXT NOT NULL,
+		words            TEXT NOT NULL,
+		price_usd        REAL NOT NULL,
+		release_year     INTEGER NOT NULL,
+		ram_bits         INTEGER NOT NULL,
+		cpu_cores        INTEGER NOT NULL,
+		cpu_min_ghz      REAL NOT NULL,
+		storage_bits     INTEGER NOT NULL,
+		ssd_only         INTEGER NOT NULL,
+		screen_size_inch REAL NOT NULL,
+		screen_width     INTEGER NOT NULL,
+		screen_height    INTEGER NOT NULL,
+		screen_panel     INTEGER NOT NULL,
+		keyboard_layout  INTEGER NOT NULL,
+		keyboard_backlit INTEGER NOT NULL,
+		weight_kg        REAL
+	);
+	CREATE INDEX laptops_price_usd ON laptops (price_usd);
+	CREATE INDEX laptops_release_year ON laptops (release_year);
+	CREATE INDEX laptops_ram_bits ON laptops (ram_bits);
+	CREATE INDEX laptops_brand ON laptops (brand);
+
+	CREATE TABLE laptop_gpus (
+		laptop_id   TEXT NOT NULL REFERENCES laptops (id) ON DELETE CASCADE,
+		brand       TEXT NOT NULL,
+		memory_bits INTEGER NOT NULL
+	);
+	CREATE INDEX laptop_gpus_laptop_id ON laptop_gpus (laptop_id);
+
+	CREATE TABLE ratings (
+		laptop_id TEXT PRIMARY KEY,
+		count     INTEGER NOT NULL,
+		sum       REAL NOT NULL
+	);
+
+	CREATE TABLE users (
+		username        TEXT PRIMARY KEY,
+		hashed_password TEXT NOT NULL,
+		role            TEXT NOT NULL
+	);
+	`,
+}
+
+// sqlitePathEscaper escapes the characters which are not taken literally in the path of a SQLite URI filename
+var sqlitePathEscaper = strings.NewReplacer("%", "%25", "?", "%3F", "#", "%23")
+
+// OpenSQLiteDB opens the SQLite database file at the path, creating it if needed,
+// and migrates its schema to the latest version
+func OpenSQLiteDB(path string) (*sql.DB, error) {
+	params := url.Values{}
+	params.Add("_pragma", "foreign_keys(1)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Set("_txlock", "immediate")
+
+	// the path is escaped so that a ? or a # in it does not end it as in a URL
+	dsn := url.URL{Scheme: "file", Opaque: sqlitePathEscaper.Replace(path), RawQuery: params.Encode()}
+
+	db, err := sql.Open("sqlite", dsn.String())
+	if err != nil {
+		return nil, fmt.Errorf("cannot open database: %w", err)
+	}
+
+	err = migrateSQLiteDB(context.Background(), db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// migrateSQLiteDB runs the migrations the database has not run yet, the version of its
+// schema is kept in its user_version
+func migrateSQLiteDB(ctx context.Context, db *sql.DB) error {
+	var version int
+	err := db.QueryRowContext(ctx, "PRAGMA user_version").Scan(&version)
+	if err != nil {
+		return fmt.Errorf("cannot read the database version: %w", err)
+	}
+
+	if version > len(sqliteMigrations) {
+		return fmt.Errorf("database version %d is newer than the supported version %d", version, len(sqliteMigrations))
+	}
+
+	for ; version < len(sqliteMigrations); version++ {
+		err := inTx(ctx, db, func(tx *sql.Tx) error {
+			_, err := tx.ExecContext(ctx, sqliteMigrations[version])
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.ExecContext(ctx, fmt.Sprintf("PRAGMA user_version = %d", version+1))
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("cannot migrate the database to version %d: %w", version+1, err)
+		}
+	}
+
+	return nil
+}
+
+// inTx runs the function in a transaction, committed if it returns no error and rolled back otherwise
+func inTx(ctx context.Context, db *sql.DB, run func(tx *sql.Tx) error) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return conflictError(err)
+	}
+
+	err = run(tx)
+	if err != nil {
+		tx.Rollback()
+		return conflictError(err)
+	}
+
+	return conflictError(tx.Commit())
+}
+
+// conflictError wraps ErrConflict around the error if the database was busy or locked,
+// the transaction may succeed once retried
+func conflictError(err error) error {
+	var sqliteErr *sqlite.Error
+	if !errors.As(err, &sqliteErr) {
+		return err
+	}
+
+	switch sqliteErr.Code() & 0xff {
+	case sqlite3.SQLITE_BUSY, sqlite3.SQLITE_LOCKED:
+		return fmt.Errorf("%w: %w", ErrConflict, err)
+	default:
+		return err
+	}
+}
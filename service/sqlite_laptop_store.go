@@ -0,0 +1,455 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"otmane/pcbook/pb"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// SQLiteLaptopStore stores laptop in a SQLite database. The laptops are saved as protobuf
+// messages next to the columns their filters are pushed down to.
+type SQLiteLaptopStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteLaptopStore returns a new SQLiteLaptopStore using the database opened with OpenSQLiteDB
+func NewSQLiteLaptopStore(db *sql.DB) *SQLiteLaptopStore {
+	return &SQLiteLaptopStore{db: db}
+}
+
+// laptopColumns are the columns of the laptops table, in the order of laptopValues
+var laptopColumns = []string{
+	"id", "revision", "data", "brand", "words", "price_usd", "release_year", "ram_bits",
+	"cpu_cores", "cpu_min_ghz", "storage_bits", "ssd_only", "screen_size_inch", "screen_width",
+	"screen_height", "screen_panel", "keyboard_layout", "keyboard_backlit", "weight_kg",
+}
+
+var (
+	insertLaptopSQL = fmt.Sprintf(
+		"INSERT INTO laptops (%s) VALUES (%s) ON CONFLICT (id) DO NOTHING",
+		strings.Join(laptopColumns, ", "),
+		placeholders(len(laptopColumns)),
+	)
+	updateLaptopSQL = fmt.Sprintf(
+		"UPDATE laptops SET %s = ? WHERE id = ?",
+		strings.Join(laptopColumns[1:], " = ?, "),
+	)
+)
+
+// laptopValues returns the values of the columns of the laptop
+func laptopValues(laptop *pb.Laptop) ([]interface{}, error) {
+	data, err := proto.Marshal(laptop)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal laptop: %w", err)
+	}
+
+	ssdOnly := len(laptop.GetStorages()) > 0
+	for _, storage := range laptop.GetStorages() {
+		ssdOnly = ssdOnly && storage.GetDriver() == pb.Storage_SSD
+	}
+
+	var weight interface{}
+	if kg, ok := weightKg(laptop); ok {
+		weight = kg
+	}
+
+	return []interface{}{
+		laptop.GetId(),
+		int64(laptop.GetRevision()),
+		data,
+		strings.ToLower(laptop.GetBrand()),
+		" " + strings.Join(laptopWords(laptop), " "),
+		laptop.GetPriceUsd(),
+		laptop.GetReleaseYear(),
+		sqlBits(toBit(laptop.GetRam())),
+		laptop.GetCpu().GetNumberCores(),
+		laptop.GetCpu().GetMinGhz(),
+		sqlBits(totalStorageBits(laptop)),
+		ssdOnly,
+		float64(laptop.GetScreen().GetSizeInch()),
+		laptop.GetScreen().GetResolution().GetWidth(),
+		laptop.GetScreen().GetResolution().GetHeight(),
+		int32(laptop.GetScreen().GetPanel()),
+		int32(laptop.GetKeyboard().GetLayout()),
+		laptop.GetKeyboard().GetBacklit(),
+		weight,
+	}, nil
+}
+
+// sqlBits converts a number of bits to an SQLite integer, saturating the sizes that do not fit
+// so that comparing them keeps their order
+func sqlBits(bits uint64) int64 {
+	if bits > math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(bits)
+}
+
+// writeLaptop inserts or updates the row of the laptop and replaces the rows of its GPUs.
+// It returns false if the laptop to insert already exists.
+func writeLaptop(ctx context.Context, tx *sql.Tx, laptop *pb.Laptop, insert bool) (bool, error) {
+	values, err := laptopValues(laptop)
+	if err != nil {
+		return false, err
+	}
+
+	if insert {
+		result, err := tx.ExecContext(ctx, insertLaptopSQL, values...)
+		if err != nil {
+			return false, fmt.Errorf("cannot insert laptop: %w", err)
+		}
+
+		rows, err := result.RowsAffected()
+		if err != nil || rows == 0 {
+			return false, err
+		}
+	} else {
+		_, err := tx.ExecContext(ctx, updateLaptopSQL, append(values[1:], laptop.GetId())...)
+		if err != nil {
+			return false, fmt.Errorf("cannot update laptop: %w", err)
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM laptop_gpus WHERE laptop_id = ?", laptop.GetId())
+		if err != nil {
+			return false, fmt.Errorf("cannot delete laptop GPUs: %w", err)
+		}
+	}
+
+	for _, gpu := range laptop.GetGpus() {
+		_, err := tx.ExecContext(ctx,
+			"INSERT INTO laptop_gpus (laptop_id, brand, memory_bits) VALUES (?, ?, ?)",
+			laptop.GetId(), strings.ToLower(gpu.GetBrand()), sqlBits(toBit(gpu.GetMemory())),
+		)
+		if err != nil {
+			return false, fmt.Errorf("cannot insert laptop GPU: %w", err)
+		}
+	}
+
+	return true, nil
+}
+
+// findRevision returns the stored revision of the laptop, or ErrNotFound
+func findRevision(ctx context.Context, tx *sql.Tx, id string) (uint64, error) {
+	var revision int64
+	err := tx.QueryRowContext(ctx, "SELECT revision FROM laptops WHERE id = ?", id).Scan(&revision)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, fmt.Errorf("laptop %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("cannot find laptop revision: %w", err)
+	}
+
+	return uint64(revision), nil
+}
+
+// Save saves the laptop to the store
+func (store *SQLiteLaptopStore) Save(laptop *pb.Laptop) error {
+	other := deepCopy(laptop)
+	other.Revision = 1
+
+	err := inTx(context.Background(), store.db, func(tx *sql.Tx) error {
+		inserted, err := writeLaptop(context.Background(), tx, other, true)
+		if err == nil && !inserted {
+			return fmt.Errorf("laptop %s: %w", laptop.Id, ErrAlreadyExists)
+		}
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	laptop.Revision = other.Revision
+	return nil
+}
+
+// Find searches for a laptop by its ID
+func (store *SQLiteLaptopStore) Find(id string) (*pb.Laptop, error) {
+	var data []byte
+	err := store.db.QueryRow("SELECT data FROM laptops WHERE id = ?", id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("laptop %s: %w", id, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot find laptop: %w", err)
+	}
+
+	return unmarshalLaptop(data)
+}
+
+func unmarshalLaptop(data []byte) (*pb.Laptop, error) {
+	laptop := &pb.Laptop{}
+	err := proto.Unmarshal(data, laptop)
+	if err != nil {
+		return nil, fmt.Errorf("cannot unmarshal laptop: %w", err)
+	}
+
+	return laptop, nil
+}
+
+// Update replaces an existing laptop in the store and sets its revision to the next one
+func (store *SQLiteLaptopStore) Update(laptop *pb.Laptop) error {
+	other := deepCopy(laptop)
+
+	err := inTx(context.Background(), store.db, func(tx *sql.Tx) error {
+		revision, err := findRevision(context.Background(), tx, laptop.Id)
+		if err != nil {
+			return err
+		}
+		err = checkStoredRevision(laptop.Id, revision, laptop.Revision)
+		if err != nil {
+			return err
+		}
+
+		other.Revision = revision + 1
+		_, err = writeLaptop(context.Background(), tx, other, false)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	laptop.Revision = other.Revision
+	return nil
+}
+
+// Delete removes a laptop from the store by its ID
+func (store *SQLiteLaptopStore) Delete(id string, revision uint64) error {
+	return inTx(context.Background(), store.db, func(tx *sql.Tx) error {
+		stored, err := findRevision(context.Background(), tx, id)
+		if err != nil {
+			return err
+		}
+		err = checkStoredRevision(id, stored, revision)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(context.Background(), "DELETE FROM laptops WHERE id = ?", id)
+		if err != nil {
+			return fmt.Errorf("cannot delete laptop: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Search searches for laptops with filter, return one by one via found function in the order of the options
+func (store *SQLiteLaptopStore) Search(
+	ctx context.Context,
+	filter *pb.Filter,
+	options SearchOptions,
+	found func(laptop *pb.Laptop) error,
+) (string, error) {
+	cursor, err := options.validate()
+	if err != nil {
+		return "", err
+	}
+
+	err = validateFilter(filter)
+	if err != nil {
+		return "", err
+	}
+
+	terms := textWords(options.Text)
+	laptops, err := store.query(ctx, filter, terms)
+	if err != nil {
+		return "", err
+	}
+
+	var hits []searchHit
+	for _, laptop := range laptops {
+		var relevance float64
+		if options.Text != "" {
+			relevance = laptopRelevance(laptop, terms)
+			if relevance == 0 {
+				continue
+			}
+		}
+		if !options.matches(filter, laptop) {
+			continue
+		}
+
+		hit := newSearchHit(laptop, relevance, options)
+		if hit.isAfter(cursor, options) {
+			hits = append(hits, hit)
+		}
+	}
+
+	sortHits(hits, options)
+	return sendPage(hits, options, found)
+}
+
+// Facets counts the laptops matching the filter by value of their facets
+func (store *SQLiteLaptopStore) Facets(ctx context.Context, filter *pb.Filter, options FacetOptions) (*pb.Facets, error) {
+	err := options.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	err = validateFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	laptops, err := store.query(ctx, filter, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	counter := newFacetCounter(options)
+	for _, laptop := range laptops {
+		if isQualified(filter, laptop) {
+			counter.add(laptop)
+		}
+	}
+
+	return counter.facets(), nil
+}
+
+// query returns the laptops selected by the SQL conditions of the filter and the words of a text.
+// They may not all match the filter, which must be checked again on each of them.
+func (store *SQLiteLaptopStore) query(ctx context.Context, filter *pb.Filter, terms []string) ([]*pb.Laptop, error) {
+	where, args := laptopConditions(filter, terms)
+
+	rows, err := store.db.QueryContext(ctx, "SELECT data FROM laptops WHERE "+where, args...)
+	if err != nil {
+		return nil, fmt.Errorf("cannot search laptops: %w", err)
+	}
+	defer rows.Close()
+
+	var laptops []*pb.Laptop
+	for rows.Next() {
+		var data []byte
+		err := rows.Scan(&data)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read laptop: %w", err)
+		}
+
+		laptop, err := unmarshalLaptop(data)
+		if err != nil {
+			return nil, err
+		}
+		laptops = append(laptops, laptop)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("cannot search laptops: %w", err)
+	}
+
+	return laptops, nil
+}
+
+// laptopConditions returns the SQL WHERE clause selecting the laptops matching the criteria
+// set in the filter and containing the words, with its arguments
+func laptopConditions(filter *pb.Filter, terms []string) (string, []interface{}) {
+	var conditions []string
+	var args []interface{}
+	add := func(condition string, values ...interface{}) {
+		conditions = append(conditions, condition)
+		args = append(args, values...)
+	}
+
+	for _, term := range terms {
+		// the words are letters and digits only, so they contain no LIKE wildcard
+		add("words LIKE ?", "% "+term+"%")
+	}
+
+	if filter == nil {
+		filter = &pb.Filter{}
+	}
+
+	if filter.MaxPriceUsd != nil {
+		add("price_usd <= ?", filter.GetMaxPriceUsd())
+	}
+	if filter.MinPriceUsd != nil {
+		add("price_usd >= ?", filter.GetMinPriceUsd())
+	}
+	if len(filter.GetBrands()) > 0 {
+		add("brand IN ("+placeholders(len(filter.GetBrands()))+")", lowerValues(filter.GetBrands())...)
+	}
+	if filter.MinCpuCors != nil {
+		add("cpu_cores >= ?", filter.GetMinCpuCors())
+	}
+	if filter.MinCpuGhz != nil {
+		add("cpu_min_ghz >= ?", filter.GetMinCpuGhz())
+	}
+	if filter.MinRam != nil {
+		add("ram_bits >= ?", sqlBits(toBit(filter.GetMinRam())))
+	}
+
+	if len(filter.GetGpuBrands()) > 0 || filter.MinGpuMemory != nil {
+		gpuConditions := []string{"laptop_id = laptops.id"}
+		if len(filter.GetGpuBrands()) > 0 {
+			gpuConditions = append(gpuConditions, "brand IN ("+placeholders(len(filter.GetGpuBrands()))+")")
+			args = append(args, lowerValues(filter.GetGpuBrands())...)
+		}
+		if filter.MinGpuMemory != nil {
+			gpuConditions = append(gpuConditions, "memory_bits >= ?")
+			args = append(args, sqlBits(toBit(filter.GetMinGpuMemory())))
+		}
+		conditions = append(conditions, "EXISTS (SELECT 1 FROM laptop_gpus WHERE "+strings.Join(gpuConditions, " AND ")+")")
+	}
+
+	if filter.GetSsdOnly() {
+		add("ssd_only")
+	}
+	if filter.MinStorage != nil {
+		add("storage_bits >= ?", sqlBits(toBit(filter.GetMinStorage())))
+	}
+
+	if filter.MinScreenSizeInch != nil {
+		add("screen_size_inch >= ?", float64(filter.GetMinScreenSizeInch()))
+	}
+	if filter.MaxScreenSizeInch != nil {
+		add("screen_size_inch <= ?", float64(filter.GetMaxScreenSizeInch()))
+	}
+	if filter.MinResolution != nil {
+		add("screen_width >= ? AND screen_height >= ?",
+			filter.GetMinResolution().GetWidth(), filter.GetMinResolution().GetHeight())
+	}
+	if filter.Panel != nil {
+		add("screen_panel = ?", int32(filter.GetPanel()))
+	}
+	if filter.KeyboardLayout != nil {
+		add("keyboard_layout = ?", int32(filter.GetKeyboardLayout()))
+	}
+	if filter.Backlit != nil {
+		add("keyboard_backlit = ?", filter.GetBacklit())
+	}
+	if filter.MinReleaseYear != nil {
+		add("release_year >= ?", filter.GetMinReleaseYear())
+	}
+	if filter.MaxReleaseYear != nil {
+		add("release_year <= ?", filter.GetMaxReleaseYear())
+	}
+	if filter.MaxWeightKg != nil {
+		add("weight_kg <= ?", filter.GetMaxWeightKg())
+	}
+
+	if len(conditions) == 0 {
+		return "1", nil
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func lowerValues(values []string) []interface{} {
+	lower := make([]interface{}, len(values))
+	for i, value := range values {
+		lower[i] = strings.ToLower(value)
+	}
+
+	return lower
+}
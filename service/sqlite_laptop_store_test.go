@@ -0,0 +1,168 @@
+package service_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"otmane/pcbook/pb"
+	"otmane/pcbook/query"
+	"otmane/pcbook/sample"
+	"otmane/pcbook/service"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func openTestSQLiteDB(t *testing.T) string {
+	path := filepath.Join(t.TempDir(), "pcbook.db")
+
+	db, err := service.OpenSQLiteDB(path)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	return path
+}
+
+func TestSQLiteLaptopStore(t *testing.T) {
+	t.Parallel()
+
+	db, err := service.OpenSQLiteDB(openTestSQLiteDB(t))
+	require.NoError(t, err)
+	defer db.Close()
+
+	store := service.NewSQLiteLaptopStore(db)
+
+	laptop := sample.NewLaptop()
+	err = store.Save(laptop)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, laptop.Revision)
+
+	err = store.Save(laptop)
+	require.ErrorIs(t, err, service.ErrAlreadyExists)
+
+	found, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(laptop, found))
+
+	_, err = store.Find(sample.NewLaptop().Id)
+	require.ErrorIs(t, err, service.ErrNotFound)
+
+	laptop.Name = "updated"
+	laptop.Gpus = laptop.Gpus[:1]
+	err = store.Update(laptop)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, laptop.Revision)
+
+	found, err = store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(laptop, found))
+
+	err = store.Update(sample.NewLaptop())
+	require.ErrorIs(t, err, service.ErrNotFound)
+
+	err = store.Delete(laptop.Id, 1)
+	require.ErrorIs(t, err, service.ErrRevisionMismatch)
+
+	err = store.Delete(laptop.Id, 2)
+	require.NoError(t, err)
+
+	err = store.Delete(laptop.Id, 0)
+	require.ErrorIs(t, err, service.ErrNotFound)
+}
+
+// TestSQLiteLaptopStoreSearch checks that the filters pushed down to SQL select the same laptops
+// as the in-memory store
+func TestSQLiteLaptopStoreSearch(t *testing.T) {
+	t.Parallel()
+
+	db, err := service.OpenSQLiteDB(openTestSQLiteDB(t))
+	require.NoError(t, err)
+	defer db.Close()
+
+	sqliteStore := service.NewSQLiteLaptopStore(db)
+	memoryStore := service.NewInMemoryLaptopStore()
+
+	for i := 0; i < 200; i++ {
+		laptop := sample.NewLaptop()
+		switch i % 10 {
+		case 0:
+			laptop.Weight = nil
+			laptop.Storages = nil
+		case 1, 2, 3:
+			laptop.Storages = []*pb.Storage{sample.NewSSD()}
+		}
+
+		err := sqliteStore.Save(laptop)
+		require.NoError(t, err)
+		err = memoryStore.Save(laptop)
+		require.NoError(t, err)
+	}
+
+	gigabytes := func(value uint64) *pb.Memory {
+		return &pb.Memory{Value: value, Unit: pb.Memory_GIGABYTE}
+	}
+	predicate, err := query.Compile("cpu.brand = 'Intel' or price < 2000")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name    string
+		filter  *pb.Filter
+		options service.SearchOptions
+	}{
+		{name: "all"},
+		{name: "price", filter: &pb.Filter{MinPriceUsd: proto.Float64(2000), MaxPriceUsd: proto.Float64(3000)}},
+		{name: "brands", filter: &pb.Filter{Brands: []string{"APPLE", "dell"}}},
+		{name: "cpu", filter: &pb.Filter{MinCpuCors: proto.Uint32(4), MinCpuGhz: proto.Float64(2.8)}},
+		{name: "ram", filter: &pb.Filter{MinRam: gigabytes(32)}},
+		{name: "gpu", filter: &pb.Filter{GpuBrands: []string{"nvidia"}, MinGpuMemory: gigabytes(4)}},
+		{name: "storage", filter: &pb.Filter{SsdOnly: true, MinStorage: gigabytes(512)}},
+		{name: "screen", filter: &pb.Filter{
+			MinScreenSizeInch: proto.Float32(14),
+			MaxScreenSizeInch: proto.Float32(16),
+			MinResolution:     &pb.Screen_Resolution{Width: 1920, Height: 1080},
+			Panel:             pb.Screen_IPS.Enum(),
+		}},
+		{name: "keyboard", filter: &pb.Filter{KeyboardLayout: pb.Keyboard_QWERTY.Enum(), Backlit: proto.Bool(true)}},
+		{name: "year", filter: &pb.Filter{MinReleaseYear: proto.Uint32(2016), MaxReleaseYear: proto.Uint32(2018)}},
+		{name: "weight", filter: &pb.Filter{MaxWeightKg: proto.Float64(2)}},
+		{name: "text", options: service.SearchOptions{Text: "thinkpad i7"}},
+		{name: "query", options: service.SearchOptions{Query: predicate}},
+		{
+			name:   "sorted page",
+			filter: &pb.Filter{MaxPriceUsd: proto.Float64(3000)},
+			options: service.SearchOptions{
+				SortBy:   []*pb.SortOrder{{Key: pb.SortOrder_RAM, Descending: true}},
+				PageSize: 10,
+			},
+		},
+	}
+
+	search := func(store service.LaptopStore, filter *pb.Filter, options service.SearchOptions) ([]string, string) {
+		var ids []string
+		token, err := store.Search(context.Background(), filter, options, func(laptop *pb.Laptop) error {
+			ids = append(ids, laptop.Id)
+			return nil
+		})
+		require.NoError(t, err)
+		return ids, token
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			expected, expectedToken := search(memoryStore, tc.filter, tc.options)
+			ids, token := search(sqliteStore, tc.filter, tc.options)
+			require.Equal(t, expected, ids)
+			require.Equal(t, expectedToken, token)
+
+			expectedFacets, err := memoryStore.Facets(context.Background(), tc.filter, service.FacetOptions{})
+			require.NoError(t, err)
+			facets, err := sqliteStore.Facets(context.Background(), tc.filter, service.FacetOptions{})
+			require.NoError(t, err)
+			if tc.options.Text == "" && tc.options.Query == nil {
+				require.True(t, proto.Equal(expectedFacets, facets))
+			}
+		})
+	}
+}
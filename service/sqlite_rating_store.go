@@ -0,0 +1,88 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// SQLiteRatingStore stores laptop ratings in a SQLite database
+type SQLiteRatingStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteRatingStore returns a new SQLiteRatingStore using the database opened with OpenSQLiteDB
+func NewSQLiteRatingStore(db *sql.DB) *SQLiteRatingStore {
+	return &SQLiteRatingStore{db: db}
+}
+
+// Add adds a new laptop score to the store and returns its rating
+func (store *SQLiteRatingStore) Add(laptopID string, score float64) (*Rating, error) {
+	rating := &Rating{}
+	err := store.db.QueryRow(`
+		INSERT INTO ratings (laptop_id, count, sum) VALUES (?, 1, ?)
+		ON CONFLICT (laptop_id) DO UPDATE SET count = count + 1, sum = sum + excluded.sum
+		RETURNING count, sum`,
+		laptopID, score,
+	).Scan(&rating.Count, &rating.Sum)
+	if err != nil {
+		return nil, fmt.Errorf("cannot add rating: %w", err)
+	}
+
+	return rating, nil
+}
+
+// Find returns the rating of a laptop
+func (store *SQLiteRatingStore) Find(laptopID string) (*Rating, error) {
+	rating := &Rating{}
+	err := store.db.QueryRow("SELECT count, sum FROM ratings WHERE laptop_id = ?", laptopID).Scan(&rating.Count, &rating.Sum)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("rating of laptop %s: %w", laptopID, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot find rating: %w", err)
+	}
+
+	return rating, nil
+}
+
+// List returns the ratings of all the rated laptops by their ID
+func (store *SQLiteRatingStore) List() (map[string]*Rating, error) {
+	rows, err := store.db.Query("SELECT laptop_id, count, sum FROM ratings")
+	if err != nil {
+		return nil, fmt.Errorf("cannot list ratings: %w", err)
+	}
+	defer rows.Close()
+
+	ratings := make(map[string]*Rating)
+	for rows.Next() {
+		var laptopID string
+		rating := &Rating{}
+		err := rows.Scan(&laptopID, &rating.Count, &rating.Sum)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list ratings: %w", err)
+		}
+		ratings[laptopID] = rating
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list ratings: %w", err)
+	}
+
+	return ratings, nil
+}
+
+// Set replaces the rating of a laptop
+func (store *SQLiteRatingStore) Set(laptopID string, rating *Rating) error {
+	_, err := store.db.Exec(`
+		INSERT INTO ratings (laptop_id, count, sum) VALUES (?, ?, ?)
+		ON CONFLICT (laptop_id) DO UPDATE SET count = excluded.count, sum = excluded.sum`,
+		laptopID, rating.Count, rating.Sum,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot set rating: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package service_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"otmane/pcbook/sample"
+	"otmane/pcbook/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenSQLiteDB(t *testing.T) {
+	t.Parallel()
+
+	path := openTestSQLiteDB(t)
+
+	db, err := service.OpenSQLiteDB(path)
+	require.NoError(t, err)
+	err = service.NewSQLiteLaptopStore(db).Save(sample.NewLaptop())
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	// opening the database again keeps its data
+	db, err = service.OpenSQLiteDB(path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	var count int
+	err = db.QueryRow("SELECT COUNT(*) FROM laptops").Scan(&count)
+	require.NoError(t, err)
+	require.Equal(t, 1, count)
+
+	_, err = db.Exec("PRAGMA user_version = 1000")
+	require.NoError(t, err)
+	_, err = service.OpenSQLiteDB(path)
+	require.Error(t, err)
+}
+
+func TestOpenSQLiteDBEscapedPath(t *testing.T) {
+	t.Parallel()
+
+	// the characters ending the path of a URI are part of the file name
+	path := filepath.Join(t.TempDir(), "pcbook?mode=ro#1%.db")
+
+	db, err := service.OpenSQLiteDB(path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	err = service.NewSQLiteLaptopStore(db).Save(sample.NewLaptop())
+	require.NoError(t, err)
+	require.FileExists(t, path)
+
+	var foreignKeys int
+	err = db.QueryRow("PRAGMA foreign_keys").Scan(&foreignKeys)
+	require.NoError(t, err)
+	require.Equal(t, 1, foreignKeys)
+}
+
+func TestSQLiteRatingStore(t *testing.T) {
+	t.Parallel()
+
+	db, err := service.OpenSQLiteDB(openTestSQLiteDB(t))
+	require.NoError(t, err)
+	defer db.Close()
+
+	store := service.NewSQLiteRatingStore(db)
+
+	_, err = store.Find("laptop")
+	require.ErrorIs(t, err, service.ErrNotFound)
+
+	rating, err := store.Add("laptop", 8)
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: 1, Sum: 8}, rating)
+
+	rating, err = store.Add("laptop", 5)
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: 2, Sum: 13}, rating)
+
+	rating, err = store.Find("laptop")
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: 2, Sum: 13}, rating)
+}
+
+func TestSQLiteUserStore(t *testing.T) {
+	t.Parallel()
+
+	db, err := service.OpenSQLiteDB(openTestSQLiteDB(t))
+	require.NoError(t, err)
+	defer db.Close()
+
+	store := service.NewSQLiteUserStore(db)
+
+	user, err := service.NewUser("admin", "secret", "admin")
+	require.NoError(t, err)
+
+	err = store.Save(user)
+	require.NoError(t, err)
+	err = store.Save(user)
+	require.ErrorIs(t, err, service.ErrAlreadyExists)
+
+	found, err := store.Find("admin")
+	require.NoError(t, err)
+	require.Equal(t, user, found)
+	require.True(t, found.IsCorrectPassword("secret"))
+
+	_, err = store.Find("unknown")
+	require.ErrorIs(t, err, service.ErrNotFound)
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// SQLiteUserStore stores users in a SQLite database
+type SQLiteUserStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteUserStore returns a new SQLiteUserStore using the database opened with OpenSQLiteDB
+func NewSQLiteUserStore(db *sql.DB) *SQLiteUserStore {
+	return &SQLiteUserStore{db: db}
+}
+
+// Save saves a user to the store.
+func (store *SQLiteUserStore) Save(user *User) error {
+	result, err := store.db.Exec(
+		"INSERT INTO users (username, hashed_password, role) VALUES (?, ?, ?) ON CONFLICT (username) DO NOTHING",
+		user.Username, user.HashedPassword, user.Role,
+	)
+	if err != nil {
+		return fmt.Errorf("cannot insert user: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("cannot insert user: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("user %s: %w", user.Username, ErrAlreadyExists)
+	}
+
+	return nil
+}
+
+// Find finds a user by username.
+func (store *SQLiteUserStore) Find(username string) (*User, error) {
+	user := &User{}
+	err := store.db.QueryRow(
+		"SELECT username, hashed_password, role FROM users WHERE username = ?", username,
+	).Scan(&user.Username, &user.HashedPassword, &user.Role)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("user %s: %w", username, ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("cannot find user: %w", err)
+	}
+
+	return user, nil
+}
+
+// List returns all the users ordered by username.
+func (store *SQLiteUserStore) List() ([]*User, error) {
+	rows, err := store.db.Query("SELECT username, hashed_password, role FROM users ORDER BY username")
+	if err != nil {
+		return nil, fmt.Errorf("cannot list users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*User
+	for rows.Next() {
+		user := &User{}
+		err := rows.Scan(&user.Username, &user.HashedPassword, &user.Role)
+		if err != nil {
+			return nil, fmt.Errorf("cannot list users: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list users: %w", err)
+	}
+
+	return users, nil
+}
@@ -0,0 +1,109 @@
+package service_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"otmane/pcbook/service"
+	"otmane/pcbook/service/storetest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newSQLiteDB(t *testing.T) *sql.DB {
+	db, err := service.OpenSQLiteDB(openTestSQLiteDB(t))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return db
+}
+
+func TestLaptopStoreConformance(t *testing.T) {
+	t.Parallel()
+
+	t.Run("InMemory", func(t *testing.T) {
+		storetest.TestLaptopStore(t, func(t *testing.T) service.LaptopStore {
+			return service.NewInMemoryLaptopStore()
+		})
+	})
+
+	t.Run("File", func(t *testing.T) {
+		storetest.TestLaptopStore(t, func(t *testing.T) service.LaptopStore {
+			store, err := service.NewFileLaptopStore(t.TempDir())
+			require.NoError(t, err)
+			t.Cleanup(func() { store.Close() })
+
+			return store
+		})
+	})
+
+	t.Run("SQLite", func(t *testing.T) {
+		storetest.TestLaptopStore(t, func(t *testing.T) service.LaptopStore {
+			return service.NewSQLiteLaptopStore(newSQLiteDB(t))
+		})
+	})
+
+	t.Run("Sharded", func(t *testing.T) {
+		storetest.TestLaptopStore(t, func(t *testing.T) service.LaptopStore {
+			return service.NewShardedLaptopStore(4)
+		})
+	})
+
+	t.Run("Cached", func(t *testing.T) {
+		storetest.TestLaptopStore(t, func(t *testing.T) service.LaptopStore {
+			return service.NewCachedLaptopStore(service.NewInMemoryLaptopStore(), 2, time.Minute)
+		})
+	})
+}
+
+func TestRatingStoreConformance(t *testing.T) {
+	t.Parallel()
+
+	t.Run("InMemory", func(t *testing.T) {
+		storetest.TestRatingStore(t, func(t *testing.T) service.RatingStore {
+			return service.NewInMemoryRatingStore()
+		})
+	})
+
+	t.Run("SQLite", func(t *testing.T) {
+		storetest.TestRatingStore(t, func(t *testing.T) service.RatingStore {
+			return service.NewSQLiteRatingStore(newSQLiteDB(t))
+		})
+	})
+}
+
+func TestUserStoreConformance(t *testing.T) {
+	t.Parallel()
+
+	t.Run("InMemory", func(t *testing.T) {
+		storetest.TestUserStore(t, func(t *testing.T) service.UserStore {
+			return service.NewInMemoryUserStore()
+		})
+	})
+
+	t.Run("SQLite", func(t *testing.T) {
+		storetest.TestUserStore(t, func(t *testing.T) service.UserStore {
+			return service.NewSQLiteUserStore(newSQLiteDB(t))
+		})
+	})
+}
+
+func TestImageStoreConformance(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Disk", func(t *testing.T) {
+		storetest.TestImageStore(t, func(t *testing.T) service.ImageStore {
+			return service.NewDiskImageStore(t.TempDir())
+		})
+	})
+
+	t.Run("IndexedDisk", func(t *testing.T) {
+		storetest.TestImageStore(t, func(t *testing.T) service.ImageStore {
+			store, _, err := service.OpenDiskImageStore(t.TempDir())
+			require.NoError(t, err)
+			t.Cleanup(func() { store.Close() })
+			return store
+		})
+	})
+}
@@ -0,0 +1,163 @@
+package storetest
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"otmane/pcbook/service"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// TestImageStore runs the conformance tests of service.ImageStore on the stores returned by newStore
+func TestImageStore(t *testing.T, newStore func(t *testing.T) service.ImageStore) {
+	runStoreTests(t, []storeTest[service.ImageStore]{
+		{"Save", testImageSave},
+		{"ConcurrentSaves", testImageConcurrentSaves},
+		{"ListSet", testImageListSet},
+		{"Open", testImageOpen},
+		{"OpenMissing", testImageOpenMissing},
+		{"ListByLaptop", testImageListByLaptop},
+		{"Delete", testImageDelete},
+	}, newStore)
+}
+
+func testImageSave(t *testing.T, store service.ImageStore) {
+	id1, err := store.Save("laptop", ".jpg", strings.NewReader("image"))
+	require.NoError(t, err)
+	require.NotEmpty(t, id1)
+
+	// saving the same image again saves a new one
+	id2, err := store.Save("laptop", ".jpg", strings.NewReader("image"))
+	require.NoError(t, err)
+	require.NotEqual(t, id1, id2)
+
+	images, err := store.List()
+	require.NoError(t, err)
+	info := images[id1]
+	require.NotNil(t, info)
+	require.Equal(t, int64(len("image")), info.Size)
+	require.Equal(t, "6105d6cc76af400325e94d588ce511be5bfdbb73b437dc51eca43917d7a43e3d", info.Checksum)
+	require.False(t, info.CreatedAt.IsZero())
+}
+
+func testImageConcurrentSaves(t *testing.T, store service.ImageStore) {
+	ids := make([]string, CONCURRENT_WRITERS)
+	errs := concurrently(func(i int) error {
+		var err error
+		ids[i], err = store.Save("laptop", ".png", strings.NewReader("image"))
+		return err
+	})
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		require.False(t, seen[id], "duplicate image ID %s", id)
+		seen[id] = true
+	}
+}
+
+func testImageListSet(t *testing.T, store service.ImageStore) {
+	images, err := store.List()
+	require.NoError(t, err)
+	require.Empty(t, images)
+
+	imageID, err := store.Save("laptop", ".jpg", strings.NewReader("image"))
+	require.NoError(t, err)
+
+	images, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	require.Equal(t, "laptop", images[imageID].LaptopID)
+	require.Equal(t, ".jpg", images[imageID].Type)
+
+	// the path of the image is the one of its data, whatever the path of the info
+	info := &service.ImageInfo{LaptopID: "other", Type: ".jpg", Path: "/etc/passwd"}
+	err = store.Set(imageID, info)
+	require.NoError(t, err)
+
+	images, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, images, 1)
+	require.Equal(t, "other", images[imageID].LaptopID)
+	require.NotEqual(t, info.Path, images[imageID].Path)
+
+	laptopImages, err := store.ListByLaptop("other")
+	require.NoError(t, err)
+	require.Contains(t, laptopImages, imageID)
+
+	// the data of the image has another type
+	err = store.Set(imageID, &service.ImageInfo{LaptopID: "laptop", Type: ".png"})
+	require.ErrorIs(t, err, service.ErrNotFound)
+
+	err = store.Set(uuid.New().String(), &service.ImageInfo{LaptopID: "laptop", Type: ".jpg"})
+	require.ErrorIs(t, err, service.ErrNotFound)
+
+	err = store.Set("../../etc/passwd", &service.ImageInfo{LaptopID: "laptop", Type: ".jpg"})
+	require.ErrorIs(t, err, service.ErrInvalidImage)
+
+	err = store.Set(imageID, &service.ImageInfo{LaptopID: "laptop", Type: "/../x"})
+	require.ErrorIs(t, err, service.ErrInvalidImage)
+}
+
+func testImageOpen(t *testing.T, store service.ImageStore) {
+	imageID, err := store.Save("laptop", ".jpg", strings.NewReader("image"))
+	require.NoError(t, err)
+
+	info, reader, err := store.Open(imageID)
+	require.NoError(t, err)
+	defer reader.Close()
+
+	require.Equal(t, "laptop", info.LaptopID)
+	require.Equal(t, ".jpg", info.Type)
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	require.Equal(t, "image", string(data))
+}
+
+func testImageOpenMissing(t *testing.T, store service.ImageStore) {
+	_, _, err := store.Open("image")
+	require.ErrorIs(t, err, service.ErrNotFound)
+}
+
+func testImageListByLaptop(t *testing.T, store service.ImageStore) {
+	id1, err := store.Save("laptop", ".jpg", strings.NewReader("image"))
+	require.NoError(t, err)
+	id2, err := store.Save("laptop", ".png", strings.NewReader("image"))
+	require.NoError(t, err)
+	_, err = store.Save("other", ".jpg", strings.NewReader("image"))
+	require.NoError(t, err)
+
+	images, err := store.ListByLaptop("laptop")
+	require.NoError(t, err)
+	require.Len(t, images, 2)
+	require.Equal(t, ".jpg", images[id1].Type)
+	require.Equal(t, ".png", images[id2].Type)
+
+	images, err = store.ListByLaptop("missing")
+	require.NoError(t, err)
+	require.Empty(t, images)
+}
+
+func testImageDelete(t *testing.T, store service.ImageStore) {
+	imageID, err := store.Save("laptop", ".jpg", strings.NewReader("image"))
+	require.NoError(t, err)
+
+	err = store.Delete(imageID)
+	require.NoError(t, err)
+
+	_, _, err = store.Open(imageID)
+	require.ErrorIs(t, err, service.ErrNotFound)
+
+	images, err := store.ListByLaptop("laptop")
+	require.NoError(t, err)
+	require.Empty(t, images)
+
+	err = store.Delete(imageID)
+	require.ErrorIs(t, err, service.ErrNotFound)
+}
@@ -0,0 +1,236 @@
+package storetest
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"otmane/pcbook/pb"
+	"otmane/pcbook/sample"
+	"otmane/pcbook/service"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestLaptopStore runs the conformance tests of service.LaptopStore on the stores returned by newStore
+func TestLaptopStore(t *testing.T, newStore func(t *testing.T) service.LaptopStore) {
+	runStoreTests(t, []storeTest[service.LaptopStore]{
+		{"SaveFind", testLaptopSaveFind},
+		{"SaveDuplicate", testLaptopSaveDuplicate},
+		{"FindMissing", testLaptopFindMissing},
+		{"Update", testLaptopUpdate},
+		{"Delete", testLaptopDelete},
+		{"Search", testLaptopSearch},
+		{"SearchCanceled", testLaptopSearchCanceled},
+		{"SearchNonFiniteFilter", testLaptopSearchNonFiniteFilter},
+		{"ConcurrentWrites", testLaptopConcurrentWrites},
+	}, newStore)
+}
+
+func testLaptopSaveFind(t *testing.T, store service.LaptopStore) {
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, laptop.Revision)
+
+	saved := proto.Clone(laptop)
+
+	// the store keeps neither the saved laptop nor the found one
+	laptop.Name = "changed after save"
+	found, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(saved, found))
+
+	found.Name = "changed after find"
+	found, err = store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(saved, found))
+}
+
+func testLaptopSaveDuplicate(t *testing.T, store service.LaptopStore) {
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	other := sample.NewLaptop()
+	other.Id = laptop.Id
+	err = store.Save(other)
+	require.ErrorIs(t, err, service.ErrAlreadyExists)
+
+	found, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(laptop, found))
+}
+
+func testLaptopFindMissing(t *testing.T, store service.LaptopStore) {
+	_, err := store.Find(sample.NewLaptop().Id)
+	require.ErrorIs(t, err, service.ErrNotFound)
+}
+
+func testLaptopUpdate(t *testing.T, store service.LaptopStore) {
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	laptop.Name = "updated"
+	err = store.Update(laptop)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, laptop.Revision)
+
+	found, err := store.Find(laptop.Id)
+	require.NoError(t, err)
+	require.True(t, proto.Equal(laptop, found))
+
+	stale := proto.Clone(laptop).(*pb.Laptop)
+	stale.Revision = 1
+	err = store.Update(stale)
+	require.ErrorIs(t, err, service.ErrRevisionMismatch)
+
+	// revision 0 updates whatever the stored revision is
+	stale.Revision = 0
+	err = store.Update(stale)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, stale.Revision)
+
+	err = store.Update(sample.NewLaptop())
+	require.ErrorIs(t, err, service.ErrNotFound)
+}
+
+func testLaptopDelete(t *testing.T, store service.LaptopStore) {
+	laptop := sample.NewLaptop()
+	err := store.Save(laptop)
+	require.NoError(t, err)
+
+	err = store.Delete(laptop.Id, 2)
+	require.ErrorIs(t, err, service.ErrRevisionMismatch)
+
+	err = store.Delete(laptop.Id, 1)
+	require.NoError(t, err)
+
+	_, err = store.Find(laptop.Id)
+	require.ErrorIs(t, err, service.ErrNotFound)
+
+	err = store.Delete(laptop.Id, 0)
+	require.ErrorIs(t, err, service.ErrNotFound)
+
+	// the ID of a deleted laptop can be saved again
+	err = store.Save(laptop)
+	require.NoError(t, err)
+}
+
+func testLaptopSearch(t *testing.T, store service.LaptopStore) {
+	var expected []string
+	for i := 0; i < 10; i++ {
+		laptop := sample.NewLaptop()
+		laptop.Brand = "Dell"
+		laptop.PriceUsd = float64(1000 + i)
+		if i%2 == 1 {
+			laptop.Brand = "Apple"
+		} else {
+			expected = append(expected, laptop.Id)
+		}
+
+		err := store.Save(laptop)
+		require.NoError(t, err)
+	}
+
+	filter := &pb.Filter{Brands: []string{"dell"}}
+	options := service.SearchOptions{
+		SortBy:   []*pb.SortOrder{{Key: pb.SortOrder_PRICE_USD}},
+		PageSize: 2,
+	}
+
+	var ids []string
+	for pages := 1; ; pages++ {
+		token, err := store.Search(context.Background(), filter, options, func(laptop *pb.Laptop) error {
+			ids = append(ids, laptop.Id)
+			return nil
+		})
+		require.NoError(t, err)
+
+		if token == "" {
+			require.Equal(t, 3, pages)
+			break
+		}
+		options.PageToken = token
+	}
+	require.Equal(t, expected, ids)
+
+	facets, err := store.Facets(context.Background(), filter, service.FacetOptions{})
+	require.NoError(t, err)
+	require.EqualValues(t, len(expected), facets.GetTotal())
+}
+
+func testLaptopSearchCanceled(t *testing.T, store service.LaptopStore) {
+	for i := 0; i < 10; i++ {
+		err := store.Save(sample.NewLaptop())
+		require.NoError(t, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := store.Search(ctx, nil, service.SearchOptions{}, func(laptop *pb.Laptop) error {
+		return nil
+	})
+	require.Error(t, err)
+
+	_, err = store.Facets(ctx, nil, service.FacetOptions{})
+	require.Error(t, err)
+}
+
+func testLaptopSearchNonFiniteFilter(t *testing.T, store service.LaptopStore) {
+	err := store.Save(sample.NewLaptop())
+	require.NoError(t, err)
+
+	filters := []*pb.Filter{
+		{MaxPriceUsd: proto.Float64(math.NaN())},
+		{MinCpuGhz: proto.Float64(math.Inf(1))},
+		{MaxWeightKg: proto.Float64(math.Inf(-1))},
+	}
+	for _, filter := range filters {
+		_, err := store.Search(context.Background(), filter, service.SearchOptions{}, func(laptop *pb.Laptop) error {
+			return nil
+		})
+		require.ErrorIs(t, err, service.ErrInvalidFilter)
+
+		_, err = store.Facets(context.Background(), filter, service.FacetOptions{})
+		require.ErrorIs(t, err, service.ErrInvalidFilter)
+	}
+}
+
+func testLaptopConcurrentWrites(t *testing.T, store service.LaptopStore) {
+	shared := sample.NewLaptop()
+	err := store.Save(shared)
+	require.NoError(t, err)
+
+	laptops := make([]*pb.Laptop, CONCURRENT_WRITERS)
+	for i := range laptops {
+		laptops[i] = sample.NewLaptop()
+	}
+
+	errs := concurrently(func(i int) error {
+		err := store.Save(laptops[i])
+		if err != nil {
+			return err
+		}
+
+		update := proto.Clone(shared).(*pb.Laptop)
+		update.Revision = 0
+		return store.Update(update)
+	})
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	for _, laptop := range laptops {
+		found, err := store.Find(laptop.Id)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(laptop, found))
+	}
+
+	found, err := store.Find(shared.Id)
+	require.NoError(t, err)
+	require.EqualValues(t, CONCURRENT_WRITERS+1, found.Revision)
+}
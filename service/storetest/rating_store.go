@@ -0,0 +1,83 @@
+package storetest
+
+import (
+	"testing"
+
+	"otmane/pcbook/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestRatingStore runs the conformance tests of service.RatingStore on the stores returned by newStore
+func TestRatingStore(t *testing.T, newStore func(t *testing.T) service.RatingStore) {
+	runStoreTests(t, []storeTest[service.RatingStore]{
+		{"AddFind", testRatingAddFind},
+		{"FindMissing", testRatingFindMissing},
+		{"ConcurrentAdds", testRatingConcurrentAdds},
+		{"ListSet", testRatingListSet},
+	}, newStore)
+}
+
+func testRatingAddFind(t *testing.T, store service.RatingStore) {
+	rating, err := store.Add("laptop", 8)
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: 1, Sum: 8}, rating)
+
+	rating, err = store.Add("laptop", 5)
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: 2, Sum: 13}, rating)
+
+	rating, err = store.Add("other", 1)
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: 1, Sum: 1}, rating)
+
+	rating, err = store.Find("laptop")
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: 2, Sum: 13}, rating)
+}
+
+func testRatingFindMissing(t *testing.T, store service.RatingStore) {
+	_, err := store.Find("laptop")
+	require.ErrorIs(t, err, service.ErrNotFound)
+}
+
+func testRatingConcurrentAdds(t *testing.T, store service.RatingStore) {
+	errs := concurrently(func(i int) error {
+		_, err := store.Add("laptop", 2)
+		return err
+	})
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	rating, err := store.Find("laptop")
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: CONCURRENT_WRITERS, Sum: 2 * CONCURRENT_WRITERS}, rating)
+}
+
+func testRatingListSet(t *testing.T, store service.RatingStore) {
+	ratings, err := store.List()
+	require.NoError(t, err)
+	require.Empty(t, ratings)
+
+	_, err = store.Add("laptop", 8)
+	require.NoError(t, err)
+
+	err = store.Set("other", &service.Rating{Count: 3, Sum: 21})
+	require.NoError(t, err)
+
+	ratings, err = store.List()
+	require.NoError(t, err)
+	require.Equal(t, map[string]*service.Rating{
+		"laptop": {Count: 1, Sum: 8},
+		"other":  {Count: 3, Sum: 21},
+	}, ratings)
+
+	// setting a rating replaces it, and adding a score adds it to the set one
+	err = store.Set("laptop", &service.Rating{Count: 5, Sum: 40})
+	require.NoError(t, err)
+
+	rating, err := store.Add("laptop", 2)
+	require.NoError(t, err)
+	require.Equal(t, &service.Rating{Count: 6, Sum: 42}, rating)
+}
@@ -0,0 +1,53 @@
+// Package storetest provides the conformance tests of the store interfaces of the service
+// package, for the implementations to check they behave like the in-memory stores. Each
+// function runs the tests as parallel subtests of t, on a new store for each of them:
+//
+//	func TestMyLaptopStore(t *testing.T) {
+//		storetest.TestLaptopStore(t, func(t *testing.T) service.LaptopStore {
+//			return NewMyLaptopStore(t.TempDir())
+//		})
+//	}
+package storetest
+
+import (
+	"sync"
+	"testing"
+)
+
+// CONCURRENT_WRITERS is the number of goroutines writing to a store at the same time
+const CONCURRENT_WRITERS = 20
+
+// storeTest is a conformance test of a store of type S
+type storeTest[S any] struct {
+	name string
+	run  func(t *testing.T, store S)
+}
+
+// runStoreTests runs each test as a parallel subtest on a new store
+func runStoreTests[S any](t *testing.T, tests []storeTest[S], newStore func(t *testing.T) S) {
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			test.run(t, newStore(t))
+		})
+	}
+}
+
+// concurrently calls write from CONCURRENT_WRITERS goroutines with their index,
+// and returns the errors they returned
+func concurrently(write func(i int) error) []error {
+	errs := make([]error, CONCURRENT_WRITERS)
+
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = write(i)
+		}(i)
+	}
+	wg.Wait()
+
+	return errs
+}
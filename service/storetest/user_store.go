@@ -0,0 +1,101 @@
+package storetest
+
+import (
+	"fmt"
+	"testing"
+
+	"otmane/pcbook/service"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestUserStore runs the conformance tests of service.UserStore on the stores returned by newStore
+func TestUserStore(t *testing.T, newStore func(t *testing.T) service.UserStore) {
+	runStoreTests(t, []storeTest[service.UserStore]{
+		{"SaveFind", testUserSaveFind},
+		{"SaveDuplicate", testUserSaveDuplicate},
+		{"FindMissing", testUserFindMissing},
+		{"ConcurrentSaves", testUserConcurrentSaves},
+		{"List", testUserList},
+	}, newStore)
+}
+
+// newUser returns a user with a precomputed hash of the password "secret", hashing passwords is slow
+func newUser(username string) *service.User {
+	return &service.User{
+		Username:       username,
+		HashedPassword: "$2a$04$mboJS0UJTM1XGQRhkdJ/RuE5XR1uHnKUd/brwIshe/3V2q7mZvyhS",
+		Role:           "admin",
+	}
+}
+
+func testUserSaveFind(t *testing.T, store service.UserStore) {
+	user := newUser("alice")
+	err := store.Save(user)
+	require.NoError(t, err)
+
+	found, err := store.Find("alice")
+	require.NoError(t, err)
+	require.Equal(t, user, found)
+}
+
+func testUserSaveDuplicate(t *testing.T, store service.UserStore) {
+	err := store.Save(newUser("alice"))
+	require.NoError(t, err)
+
+	other := newUser("alice")
+	other.Role = "user"
+	err = store.Save(other)
+	require.ErrorIs(t, err, service.ErrAlreadyExists)
+
+	found, err := store.Find("alice")
+	require.NoError(t, err)
+	require.Equal(t, "admin", found.Role)
+}
+
+func testUserFindMissing(t *testing.T, store service.UserStore) {
+	_, err := store.Find("alice")
+	require.ErrorIs(t, err, service.ErrNotFound)
+}
+
+func testUserConcurrentSaves(t *testing.T, store service.UserStore) {
+	errs := concurrently(func(i int) error {
+		err := store.Save(newUser(fmt.Sprintf("user%d", i)))
+		if err != nil {
+			return err
+		}
+
+		return store.Save(newUser("shared"))
+	})
+
+	// every writer saves its own user, and exactly one of them saves the shared one
+	saved := 0
+	for _, err := range errs {
+		if err == nil {
+			saved++
+		} else {
+			require.ErrorIs(t, err, service.ErrAlreadyExists)
+		}
+	}
+	require.Equal(t, 1, saved)
+
+	for i := 0; i < CONCURRENT_WRITERS; i++ {
+		_, err := store.Find(fmt.Sprintf("user%d", i))
+		require.NoError(t, err)
+	}
+}
+
+func testUserList(t *testing.T, store service.UserStore) {
+	users, err := store.List()
+	require.NoError(t, err)
+	require.Empty(t, users)
+
+	for _, username := range []string{"carol", "alice", "bob"} {
+		err := store.Save(newUser(username))
+		require.NoError(t, err)
+	}
+
+	users, err = store.List()
+	require.NoError(t, err)
+	require.Equal(t, []*service.User{newUser("alice"), newUser("bob"), newUser("carol")}, users)
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+
+	"otmane/pcbook/pb"
+
+	"github.com/google/btree"
+)
+
+const (
+	exactWordScore  = 2
+	prefixWordScore = 1
+)
+
+// textIndex is an inverted index of the words of the laptops brand, name, CPU and GPUs,
+// sorted by word for prefix matching
+type textIndex struct {
+	postings *btree.BTreeG[textEntry]
+}
+
+// textEntry is a word of a laptop, ordered by word and then laptop ID
+type textEntry struct {
+	word string
+	id   string
+}
+
+func lessTextEntry(a, b textEntry) bool {
+	if a.word != b.word {
+		return a.word < b.word
+	}
+	return a.id < b.id
+}
+
+func newTextIndex() *textIndex {
+	return &textIndex{
+		postings: btree.NewG(indexDegree, lessTextEntry),
+	}
+}
+
+// clone returns a copy of the index in constant time, see laptopTable.snapshot
+func (index *textIndex) clone() *textIndex {
+	return &textIndex{postings: index.postings.Clone()}
+}
+
+// textWords splits a text into lower case words of letters and digits
+func textWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// laptopWords returns the distinct words of the laptop that can be searched
+func laptopWords(laptop *pb.Laptop) []string {
+	texts := []string{
+		laptop.GetBrand(),
+		laptop.GetName(),
+		laptop.GetCpu().GetBrand(),
+		laptop.GetCpu().GetName(),
+	}
+	for _, gpu := range laptop.GetGpus() {
+		texts = append(texts, gpu.GetBrand(), gpu.GetName())
+	}
+
+	seen := make(map[string]bool)
+	var words []string
+	for _, text := range texts {
+		for _, word := range textWords(text) {
+			if !seen[word] {
+				seen[word] = true
+				words = append(words, word)
+			}
+		}
+	}
+
+	return words
+}
+
+// add indexes the words of the laptop
+func (index *textIndex) add(laptop *pb.Laptop) {
+	for _, word := range laptopWords(laptop) {
+		index.postings.ReplaceOrInsert(textEntry{word: word, id: laptop.GetId()})
+	}
+}
+
+// remove removes the words of the laptop from the index
+func (index *textIndex) remove(laptop *pb.Laptop) {
+	for _, word := range laptopWords(laptop) {
+		index.postings.Delete(textEntry{word: word, id: laptop.GetId()})
+	}
+}
+
+// search returns the relevance of the laptops containing every word of the text,
+// as a whole word or as a prefix. Whole words are more relevant than prefixes.
+func (index *textIndex) search(text string) map[string]float64 {
+	var scores map[string]float64
+
+	for _, term := range textWords(text) {
+		termScores := make(map[string]float64)
+
+		index.postings.AscendGreaterOrEqual(textEntry{word: term}, func(entry textEntry) bool {
+			if !strings.HasPrefix(entry.word, term) {
+				return false
+			}
+
+			score := float64(prefixWordScore)
+			if entry.word == term {
+				score = exactWordScore
+			}
+			if score > termScores[entry.id] {
+				termScores[entry.id] = score
+			}
+			return true
+		})
+
+		if scores == nil {
+			scores = termScores
+			continue
+		}
+
+		for id, score := range scores {
+			if termScores[id] == 0 {
+				delete(scores, id)
+			} else {
+				scores[id] = score + termScores[id]
+			}
+		}
+	}
+
+	if scores == nil {
+		scores = make(map[string]float64)
+	}
+
+	return scores
+}
+
+// laptopRelevance returns the relevance of the laptop for the words of a text,
+// scored like search, or 0 if it does not contain all of them
+func laptopRelevance(laptop *pb.Laptop, terms []string) float64 {
+	if len(terms) == 0 {
+		return 0
+	}
+
+	words := laptopWords(laptop)
+
+	var relevance float64
+	for _, term := range terms {
+		var termScore float64
+		for _, word := range words {
+			if word == term {
+				termScore = exactWordScore
+				break
+			}
+			if strings.HasPrefix(word, term) {
+				termScore = prefixWordScore
+			}
+		}
+
+		if termScore == 0 {
+			return 0
+		}
+		relevance += termScore
+	}
+
+	return relevance
+}
@@ -1,6 +1,10 @@
 package service
 
-import "sync"
+import (
+    "fmt"
+    "sort"
+    "sync"
+)
 
 // UserStore is an interface to store users.
 type UserStore interface {
@@ -8,6 +12,8 @@ type UserStore interface {
     Save(user *User) error
     // Find finds a user by username.
     Find(username string) (*User, error)
+    // List returns all the users ordered by username.
+    List() ([]*User, error)
 }
 
 type InMemoryUserStore struct {
@@ -27,7 +33,7 @@ func (store *InMemoryUserStore) Save(user *User) error {
     defer store.mu.Unlock()
 
     if store.users[user.Username] != nil {
-        return ErrAlreadyExists
+        return fmt.Errorf("user %s: %w", user.Username, ErrAlreadyExists)
     }
 
     store.users[user.Username] = user
@@ -40,8 +46,25 @@ func (store *InMemoryUserStore) Find(username string) (*User, error) {
     defer store.mu.RUnlock()
 
     if store.users[username] == nil {
-        return nil, ErrNotFound
+        return nil, fmt.Errorf("user %s: %w", username, ErrNotFound)
     }
 
     return store.users[username], nil
 }
+
+// List returns all the users ordered by username.
+func (store *InMemoryUserStore) List() ([]*User, error) {
+    store.mu.RLock()
+    defer store.mu.RUnlock()
+
+    users := make([]*User, 0, len(store.users))
+    for _, user := range store.users {
+        users = append(users, user.Clone())
+    }
+
+    sort.Slice(users, func(i, j int) bool {
+        return users[i].Username < users[j].Username
+    })
+
+    return users, nil
+}